@@ -0,0 +1,2791 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v3.12.4
+// source: exchange/v1/exchange.proto
+
+package exchangev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Критерий выбора пути конвертации
+type PathStrategy int32
+
+const (
+	PathStrategy_PATH_STRATEGY_UNSPECIFIED PathStrategy = 0 // то же, что PATH_STRATEGY_FEWEST_HOPS
+	PathStrategy_PATH_STRATEGY_FEWEST_HOPS PathStrategy = 1 // минимальное число шагов
+	PathStrategy_PATH_STRATEGY_FRESHEST    PathStrategy = 2 // максимально свежий самый старый курс пути
+)
+
+// Enum value maps for PathStrategy.
+var (
+	PathStrategy_name = map[int32]string{
+		0: "PATH_STRATEGY_UNSPECIFIED",
+		1: "PATH_STRATEGY_FEWEST_HOPS",
+		2: "PATH_STRATEGY_FRESHEST",
+	}
+	PathStrategy_value = map[string]int32{
+		"PATH_STRATEGY_UNSPECIFIED": 0,
+		"PATH_STRATEGY_FEWEST_HOPS": 1,
+		"PATH_STRATEGY_FRESHEST":    2,
+	}
+)
+
+func (x PathStrategy) Enum() *PathStrategy {
+	p := new(PathStrategy)
+	*p = x
+	return p
+}
+
+func (x PathStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PathStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_exchange_v1_exchange_proto_enumTypes[0].Descriptor()
+}
+
+func (PathStrategy) Type() protoreflect.EnumType {
+	return &file_exchange_v1_exchange_proto_enumTypes[0]
+}
+
+func (x PathStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PathStrategy.Descriptor instead.
+func (PathStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{0}
+}
+
+// Режим округления суммы
+type RoundingMode int32
+
+const (
+	RoundingMode_ROUNDING_MODE_UNSPECIFIED RoundingMode = 0 // то же, что ROUNDING_MODE_HALF_EVEN
+	RoundingMode_ROUNDING_MODE_HALF_EVEN   RoundingMode = 1 // к ближайшему, при равенстве — к чётному (банковское)
+	RoundingMode_ROUNDING_MODE_HALF_UP     RoundingMode = 2 // к ближайшему, при равенстве — от нуля
+	RoundingMode_ROUNDING_MODE_DOWN        RoundingMode = 3 // отбрасывание дробной части (к нулю)
+)
+
+// Enum value maps for RoundingMode.
+var (
+	RoundingMode_name = map[int32]string{
+		0: "ROUNDING_MODE_UNSPECIFIED",
+		1: "ROUNDING_MODE_HALF_EVEN",
+		2: "ROUNDING_MODE_HALF_UP",
+		3: "ROUNDING_MODE_DOWN",
+	}
+	RoundingMode_value = map[string]int32{
+		"ROUNDING_MODE_UNSPECIFIED": 0,
+		"ROUNDING_MODE_HALF_EVEN":   1,
+		"ROUNDING_MODE_HALF_UP":     2,
+		"ROUNDING_MODE_DOWN":        3,
+	}
+)
+
+func (x RoundingMode) Enum() *RoundingMode {
+	p := new(RoundingMode)
+	*p = x
+	return p
+}
+
+func (x RoundingMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoundingMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_exchange_v1_exchange_proto_enumTypes[1].Descriptor()
+}
+
+func (RoundingMode) Type() protoreflect.EnumType {
+	return &file_exchange_v1_exchange_proto_enumTypes[1]
+}
+
+func (x RoundingMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoundingMode.Descriptor instead.
+func (RoundingMode) EnumDescriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{1}
+}
+
+// Ширина интервала истории курса
+type HistoryInterval int32
+
+const (
+	HistoryInterval_HISTORY_INTERVAL_UNSPECIFIED HistoryInterval = 0
+	HistoryInterval_HISTORY_INTERVAL_MINUTE      HistoryInterval = 1 // 1m
+	HistoryInterval_HISTORY_INTERVAL_HOUR        HistoryInterval = 2 // 1h
+	HistoryInterval_HISTORY_INTERVAL_DAY         HistoryInterval = 3 // 1d
+)
+
+// Enum value maps for HistoryInterval.
+var (
+	HistoryInterval_name = map[int32]string{
+		0: "HISTORY_INTERVAL_UNSPECIFIED",
+		1: "HISTORY_INTERVAL_MINUTE",
+		2: "HISTORY_INTERVAL_HOUR",
+		3: "HISTORY_INTERVAL_DAY",
+	}
+	HistoryInterval_value = map[string]int32{
+		"HISTORY_INTERVAL_UNSPECIFIED": 0,
+		"HISTORY_INTERVAL_MINUTE":      1,
+		"HISTORY_INTERVAL_HOUR":        2,
+		"HISTORY_INTERVAL_DAY":         3,
+	}
+)
+
+func (x HistoryInterval) Enum() *HistoryInterval {
+	p := new(HistoryInterval)
+	*p = x
+	return p
+}
+
+func (x HistoryInterval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HistoryInterval) Descriptor() protoreflect.EnumDescriptor {
+	return file_exchange_v1_exchange_proto_enumTypes[2].Descriptor()
+}
+
+func (HistoryInterval) Type() protoreflect.EnumType {
+	return &file_exchange_v1_exchange_proto_enumTypes[2]
+}
+
+func (x HistoryInterval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HistoryInterval.Descriptor instead.
+func (HistoryInterval) EnumDescriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{2}
+}
+
+// Запрос курса обмена для валютной пары
+type RateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"` // момент времени; если не задан — текущий курс. Ручные фиксации для as_of не учитываются
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateRequest) Reset() {
+	*x = RateRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateRequest) ProtoMessage() {}
+
+func (x *RateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateRequest.ProtoReflect.Descriptor instead.
+func (*RateRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RateRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *RateRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *RateRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+// Ответ с курсом обмена для валютной пары
+type RateResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency   string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	// Deprecated: Marked as deprecated in exchange/v1/exchange.proto.
+	Rate              float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`                                                    // приближённое значение, используйте rate_decimal
+	AsOf              *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`                                          // момент времени, на который получен курс
+	RateDecimal       *Decimal               `protobuf:"bytes,5,opt,name=rate_decimal,json=rateDecimal,proto3" json:"rate_decimal,omitempty"`                     // точное значение курса
+	Derived           bool                   `protobuf:"varint,6,opt,name=derived,proto3" json:"derived,omitempty"`                                               // курс не хранится, а вычислен из других курсов
+	Legs              []*RateLeg             `protobuf:"bytes,7,rep,name=legs,proto3" json:"legs,omitempty"`                                                      // курсы, из которых вычислен производный курс
+	Override          bool                   `protobuf:"varint,8,opt,name=override,proto3" json:"override,omitempty"`                                             // курс зафиксирован вручную
+	OverrideExpiresAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=override_expires_at,json=overrideExpiresAt,proto3" json:"override_expires_at,omitempty"` // окончание действия ручной фиксации
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RateResponse) Reset() {
+	*x = RateResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateResponse) ProtoMessage() {}
+
+func (x *RateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateResponse.ProtoReflect.Descriptor instead.
+func (*RateResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RateResponse) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *RateResponse) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in exchange/v1/exchange.proto.
+func (x *RateResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *RateResponse) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *RateResponse) GetRateDecimal() *Decimal {
+	if x != nil {
+		return x.RateDecimal
+	}
+	return nil
+}
+
+func (x *RateResponse) GetDerived() bool {
+	if x != nil {
+		return x.Derived
+	}
+	return false
+}
+
+func (x *RateResponse) GetLegs() []*RateLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *RateResponse) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
+}
+
+func (x *RateResponse) GetOverrideExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OverrideExpiresAt
+	}
+	return nil
+}
+
+// Хранимый курс, использованный для вычисления производного курса
+type RateLeg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Rate          *Decimal               `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // время обновления курса; не заполняется для курса на прошлый момент (as_of)
+	Override      bool                   `protobuf:"varint,5,opt,name=override,proto3" json:"override,omitempty"`                   // курс зафиксирован вручную
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLeg) Reset() {
+	*x = RateLeg{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLeg) ProtoMessage() {}
+
+func (x *RateLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLeg.ProtoReflect.Descriptor instead.
+func (*RateLeg) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RateLeg) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *RateLeg) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *RateLeg) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *RateLeg) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *RateLeg) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
+}
+
+// Запрос поиска пути конвертации
+type ConversionPathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Strategy      PathStrategy           `protobuf:"varint,3,opt,name=strategy,proto3,enum=exchange.v1.PathStrategy" json:"strategy,omitempty"`
+	MaxHops       uint32                 `protobuf:"varint,4,opt,name=max_hops,json=maxHops,proto3" json:"max_hops,omitempty"` // максимальное число шагов; 0 — значение по умолчанию
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversionPathRequest) Reset() {
+	*x = ConversionPathRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversionPathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversionPathRequest) ProtoMessage() {}
+
+func (x *ConversionPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversionPathRequest.ProtoReflect.Descriptor instead.
+func (*ConversionPathRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConversionPathRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConversionPathRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConversionPathRequest) GetStrategy() PathStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return PathStrategy_PATH_STRATEGY_UNSPECIFIED
+}
+
+func (x *ConversionPathRequest) GetMaxHops() uint32 {
+	if x != nil {
+		return x.MaxHops
+	}
+	return 0
+}
+
+// Ответ с найденным путём конвертации
+type ConversionPathResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Rate          *Decimal               `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"` // итоговый курс — произведение курсов пути
+	Legs          []*RateLeg             `protobuf:"bytes,4,rep,name=legs,proto3" json:"legs,omitempty"` // курсы пути в порядке применения
+	Strategy      PathStrategy           `protobuf:"varint,5,opt,name=strategy,proto3,enum=exchange.v1.PathStrategy" json:"strategy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversionPathResponse) Reset() {
+	*x = ConversionPathResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversionPathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversionPathResponse) ProtoMessage() {}
+
+func (x *ConversionPathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversionPathResponse.ProtoReflect.Descriptor instead.
+func (*ConversionPathResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConversionPathResponse) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConversionPathResponse) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConversionPathResponse) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *ConversionPathResponse) GetLegs() []*RateLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *ConversionPathResponse) GetStrategy() PathStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return PathStrategy_PATH_STRATEGY_UNSPECIFIED
+}
+
+// Точное десятичное значение.
+// value — строковое представление (например, "92.123456"),
+// units и nanos — целая и дробная (в миллиардных долях) части с одинаковым знаком.
+type Decimal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Units         int64                  `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
+	Nanos         int32                  `protobuf:"varint,3,opt,name=nanos,proto3" json:"nanos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Decimal) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Decimal) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Decimal) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
+
+// Запись курса обмена валютной пары
+type ExchangeRate struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ExchangeRateId string                 `protobuf:"bytes,1,opt,name=exchange_rate_id,json=exchangeRateId,proto3" json:"exchange_rate_id,omitempty"`
+	FromCurrency   string                 `protobuf:"bytes,2,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency     string                 `protobuf:"bytes,3,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	// Deprecated: Marked as deprecated in exchange/v1/exchange.proto.
+	Rate          float64                `protobuf:"fixed64,4,opt,name=rate,proto3" json:"rate,omitempty"` // приближённое значение, используйте rate_decimal
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RateDecimal   *Decimal               `protobuf:"bytes,7,opt,name=rate_decimal,json=rateDecimal,proto3" json:"rate_decimal,omitempty"` // точное значение курса
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRate) Reset() {
+	*x = ExchangeRate{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRate) ProtoMessage() {}
+
+func (x *ExchangeRate) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRate.ProtoReflect.Descriptor instead.
+func (*ExchangeRate) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExchangeRate) GetExchangeRateId() string {
+	if x != nil {
+		return x.ExchangeRateId
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in exchange/v1/exchange.proto.
+func (x *ExchangeRate) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ExchangeRate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ExchangeRate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ExchangeRate) GetRateDecimal() *Decimal {
+	if x != nil {
+		return x.RateDecimal
+	}
+	return nil
+}
+
+// Запрос на создание или обновление курса валютной пары
+type UpsertRateRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency   string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	// Deprecated: Marked as deprecated in exchange/v1/exchange.proto.
+	Rate          float64  `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`                                // используется, если rate_decimal не задан
+	RateDecimal   *Decimal `protobuf:"bytes,4,opt,name=rate_decimal,json=rateDecimal,proto3" json:"rate_decimal,omitempty"` // точное значение курса
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertRateRequest) Reset() {
+	*x = UpsertRateRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertRateRequest) ProtoMessage() {}
+
+func (x *UpsertRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertRateRequest.ProtoReflect.Descriptor instead.
+func (*UpsertRateRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpsertRateRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *UpsertRateRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in exchange/v1/exchange.proto.
+func (x *UpsertRateRequest) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *UpsertRateRequest) GetRateDecimal() *Decimal {
+	if x != nil {
+		return x.RateDecimal
+	}
+	return nil
+}
+
+// Запрос на создание или обновление нескольких курсов
+type UpsertRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*UpsertRateRequest   `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertRatesRequest) Reset() {
+	*x = UpsertRatesRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertRatesRequest) ProtoMessage() {}
+
+func (x *UpsertRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertRatesRequest.ProtoReflect.Descriptor instead.
+func (*UpsertRatesRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpsertRatesRequest) GetRates() []*UpsertRateRequest {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+// Ответ с сохранёнными курсами
+type UpsertRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*ExchangeRate        `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertRatesResponse) Reset() {
+	*x = UpsertRatesResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertRatesResponse) ProtoMessage() {}
+
+func (x *UpsertRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertRatesResponse.ProtoReflect.Descriptor instead.
+func (*UpsertRatesResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpsertRatesResponse) GetRates() []*ExchangeRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+// Запрос на удаление курса валютной пары
+type DeleteRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRateRequest) Reset() {
+	*x = DeleteRateRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRateRequest) ProtoMessage() {}
+
+func (x *DeleteRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRateRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteRateRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *DeleteRateRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+// Ответ на удаление курса валютной пары
+type DeleteRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"` // false, если курс для пары не существовал
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRateResponse) Reset() {
+	*x = DeleteRateResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRateResponse) ProtoMessage() {}
+
+func (x *DeleteRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRateResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteRateResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Запрос конвертации суммы
+type ConvertAmountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Amount        *Decimal               `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"` // сумма в исходной валюте
+	RoundingMode  RoundingMode           `protobuf:"varint,4,opt,name=rounding_mode,json=roundingMode,proto3,enum=exchange.v1.RoundingMode" json:"rounding_mode,omitempty"`
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"` // момент времени курса; если не задан — текущий курс. Ручные фиксации для as_of не учитываются
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertAmountRequest) Reset() {
+	*x = ConvertAmountRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertAmountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertAmountRequest) ProtoMessage() {}
+
+func (x *ConvertAmountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertAmountRequest.ProtoReflect.Descriptor instead.
+func (*ConvertAmountRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConvertAmountRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConvertAmountRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConvertAmountRequest) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ConvertAmountRequest) GetRoundingMode() RoundingMode {
+	if x != nil {
+		return x.RoundingMode
+	}
+	return RoundingMode_ROUNDING_MODE_UNSPECIFIED
+}
+
+func (x *ConvertAmountRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+// Ответ с конвертированной суммой
+type ConvertAmountResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency    string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency      string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Amount          *Decimal               `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`                                          // исходная сумма
+	ConvertedAmount *Decimal               `protobuf:"bytes,4,opt,name=converted_amount,json=convertedAmount,proto3" json:"converted_amount,omitempty"` // сумма в целевой валюте, округлённая до minor_units знаков
+	Rate            *Decimal               `protobuf:"bytes,5,opt,name=rate,proto3" json:"rate,omitempty"`                                              // использованный курс
+	MinorUnits      int32                  `protobuf:"varint,6,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`               // число знаков после запятой целевой валюты
+	RoundingMode    RoundingMode           `protobuf:"varint,7,opt,name=rounding_mode,json=roundingMode,proto3,enum=exchange.v1.RoundingMode" json:"rounding_mode,omitempty"`
+	Derived         bool                   `protobuf:"varint,8,opt,name=derived,proto3" json:"derived,omitempty"` // курс вычислен из других курсов
+	AsOf            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConvertAmountResponse) Reset() {
+	*x = ConvertAmountResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertAmountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertAmountResponse) ProtoMessage() {}
+
+func (x *ConvertAmountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertAmountResponse.ProtoReflect.Descriptor instead.
+func (*ConvertAmountResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConvertAmountResponse) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConvertAmountResponse) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConvertAmountResponse) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ConvertAmountResponse) GetConvertedAmount() *Decimal {
+	if x != nil {
+		return x.ConvertedAmount
+	}
+	return nil
+}
+
+func (x *ConvertAmountResponse) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *ConvertAmountResponse) GetMinorUnits() int32 {
+	if x != nil {
+		return x.MinorUnits
+	}
+	return 0
+}
+
+func (x *ConvertAmountResponse) GetRoundingMode() RoundingMode {
+	if x != nil {
+		return x.RoundingMode
+	}
+	return RoundingMode_ROUNDING_MODE_UNSPECIFIED
+}
+
+func (x *ConvertAmountResponse) GetDerived() bool {
+	if x != nil {
+		return x.Derived
+	}
+	return false
+}
+
+func (x *ConvertAmountResponse) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+// Валюта справочника ISO 4217
+type Currency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                                   // буквенный код
+	NumericCode   int32                  `protobuf:"varint,2,opt,name=numeric_code,json=numericCode,proto3" json:"numeric_code,omitempty"` // числовой код
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`                            // символ валюты; пусто, если не задан
+	MinorUnits    int32                  `protobuf:"varint,5,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"` // число знаков после запятой
+	Enabled       bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled,omitempty"`                         // валюта принимается сервисом
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Currency) Reset() {
+	*x = Currency{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Currency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Currency) ProtoMessage() {}
+
+func (x *Currency) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Currency.ProtoReflect.Descriptor instead.
+func (*Currency) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Currency) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Currency) GetNumericCode() int32 {
+	if x != nil {
+		return x.NumericCode
+	}
+	return 0
+}
+
+func (x *Currency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Currency) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Currency) GetMinorUnits() int32 {
+	if x != nil {
+		return x.MinorUnits
+	}
+	return 0
+}
+
+func (x *Currency) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// Запрос справочника валют
+type ListCurrenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       *wrapperspb.BoolValue  `protobuf:"bytes,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // фильтр по флагу enabled; если не задан — все валюты
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesRequest) Reset() {
+	*x = ListCurrenciesRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesRequest) ProtoMessage() {}
+
+func (x *ListCurrenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListCurrenciesRequest) GetEnabled() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Enabled
+	}
+	return nil
+}
+
+// Ответ со справочником валют, упорядоченным по коду
+type ListCurrenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currencies    []*Currency            `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesResponse) Reset() {
+	*x = ListCurrenciesResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesResponse) ProtoMessage() {}
+
+func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListCurrenciesResponse) GetCurrencies() []*Currency {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+// Запрос списка курсов валютных пар
+type ListRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseCurrency  string                 `protobuf:"bytes,1,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`    // фильтр по исходной валюте (from_currency); если пусто — все
+	QuoteCurrency string                 `protobuf:"bytes,2,opt,name=quote_currency,json=quoteCurrency,proto3" json:"quote_currency,omitempty"` // фильтр по целевой валюте (to_currency); если пусто — все
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRatesRequest) Reset() {
+	*x = ListRatesRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRatesRequest) ProtoMessage() {}
+
+func (x *ListRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRatesRequest.ProtoReflect.Descriptor instead.
+func (*ListRatesRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListRatesRequest) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *ListRatesRequest) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+// Ответ со списком курсов, упорядоченным по исходной и целевой валюте
+type ListRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*ExchangeRate        `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRatesResponse) Reset() {
+	*x = ListRatesResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRatesResponse) ProtoMessage() {}
+
+func (x *ListRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRatesResponse.ProtoReflect.Descriptor instead.
+func (*ListRatesResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListRatesResponse) GetRates() []*ExchangeRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+// Валютная пара
+type CurrencyPair struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyPair) Reset() {
+	*x = CurrencyPair{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyPair) ProtoMessage() {}
+
+func (x *CurrencyPair) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyPair.ProtoReflect.Descriptor instead.
+func (*CurrencyPair) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CurrencyPair) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *CurrencyPair) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+// Запрос подписки на изменения курсов
+type SubscribeRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pairs         []*CurrencyPair        `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"` // пары для подписки; если пусто — все пары
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRatesRequest) Reset() {
+	*x = SubscribeRatesRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRatesRequest) ProtoMessage() {}
+
+func (x *SubscribeRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRatesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRatesRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SubscribeRatesRequest) GetPairs() []*CurrencyPair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+// Сообщение потока изменений курсов.
+// Если клиент не успевает читать поток, изменения одной пары объединяются и приходит только последний курс.
+type SubscribeRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      bool                   `protobuf:"varint,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // true для первого сообщения со снимком текущих курсов
+	Updated       []*ExchangeRate        `protobuf:"bytes,2,rep,name=updated,proto3" json:"updated,omitempty"`    // созданные или изменённые курсы
+	Deleted       []*CurrencyPair        `protobuf:"bytes,3,rep,name=deleted,proto3" json:"deleted,omitempty"`    // удалённые пары
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRatesResponse) Reset() {
+	*x = SubscribeRatesResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRatesResponse) ProtoMessage() {}
+
+func (x *SubscribeRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRatesResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeRatesResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SubscribeRatesResponse) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *SubscribeRatesResponse) GetUpdated() []*ExchangeRate {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *SubscribeRatesResponse) GetDeleted() []*CurrencyPair {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+// Запрос истории курса валютной пары за период [start, end)
+type RateHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	Interval      HistoryInterval        `protobuf:"varint,5,opt,name=interval,proto3,enum=exchange.v1.HistoryInterval" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateHistoryRequest) Reset() {
+	*x = RateHistoryRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateHistoryRequest) ProtoMessage() {}
+
+func (x *RateHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateHistoryRequest.ProtoReflect.Descriptor instead.
+func (*RateHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RateHistoryRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *RateHistoryRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *RateHistoryRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *RateHistoryRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *RateHistoryRequest) GetInterval() HistoryInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return HistoryInterval_HISTORY_INTERVAL_UNSPECIFIED
+}
+
+// Курс на конец интервала истории
+type RatePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"` // начало интервала
+	Rate          *Decimal               `protobuf:"bytes,2,opt,name=rate,proto3" json:"rate,omitempty"` // последний курс, действовавший в интервале
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatePoint) Reset() {
+	*x = RatePoint{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatePoint) ProtoMessage() {}
+
+func (x *RatePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatePoint.ProtoReflect.Descriptor instead.
+func (*RatePoint) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RatePoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *RatePoint) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+// Ответ с историей курса, по одной точке на интервал начиная с первого известного курса
+type RateHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Interval      HistoryInterval        `protobuf:"varint,3,opt,name=interval,proto3,enum=exchange.v1.HistoryInterval" json:"interval,omitempty"`
+	Points        []*RatePoint           `protobuf:"bytes,4,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateHistoryResponse) Reset() {
+	*x = RateHistoryResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateHistoryResponse) ProtoMessage() {}
+
+func (x *RateHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateHistoryResponse.ProtoReflect.Descriptor instead.
+func (*RateHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RateHistoryResponse) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *RateHistoryResponse) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *RateHistoryResponse) GetInterval() HistoryInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return HistoryInterval_HISTORY_INTERVAL_UNSPECIFIED
+}
+
+func (x *RateHistoryResponse) GetPoints() []*RatePoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// Запрос свечей курса валютной пары за период [start, end)
+type RateCandlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	Interval      HistoryInterval        `protobuf:"varint,5,opt,name=interval,proto3,enum=exchange.v1.HistoryInterval" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateCandlesRequest) Reset() {
+	*x = RateCandlesRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateCandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateCandlesRequest) ProtoMessage() {}
+
+func (x *RateCandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateCandlesRequest.ProtoReflect.Descriptor instead.
+func (*RateCandlesRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RateCandlesRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *RateCandlesRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *RateCandlesRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *RateCandlesRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *RateCandlesRequest) GetInterval() HistoryInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return HistoryInterval_HISTORY_INTERVAL_UNSPECIFIED
+}
+
+// Свеча курса за интервал
+type RateCandle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`        // начало интервала
+	Open          *Decimal               `protobuf:"bytes,2,opt,name=open,proto3" json:"open,omitempty"`        // курс на начало интервала
+	High          *Decimal               `protobuf:"bytes,3,opt,name=high,proto3" json:"high,omitempty"`        // максимальный курс в интервале
+	Low           *Decimal               `protobuf:"bytes,4,opt,name=low,proto3" json:"low,omitempty"`          // минимальный курс в интервале
+	Close         *Decimal               `protobuf:"bytes,5,opt,name=close,proto3" json:"close,omitempty"`      // курс на конец интервала
+	Changes       int64                  `protobuf:"varint,6,opt,name=changes,proto3" json:"changes,omitempty"` // число изменений курса в интервале
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateCandle) Reset() {
+	*x = RateCandle{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateCandle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateCandle) ProtoMessage() {}
+
+func (x *RateCandle) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateCandle.ProtoReflect.Descriptor instead.
+func (*RateCandle) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RateCandle) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *RateCandle) GetOpen() *Decimal {
+	if x != nil {
+		return x.Open
+	}
+	return nil
+}
+
+func (x *RateCandle) GetHigh() *Decimal {
+	if x != nil {
+		return x.High
+	}
+	return nil
+}
+
+func (x *RateCandle) GetLow() *Decimal {
+	if x != nil {
+		return x.Low
+	}
+	return nil
+}
+
+func (x *RateCandle) GetClose() *Decimal {
+	if x != nil {
+		return x.Close
+	}
+	return nil
+}
+
+func (x *RateCandle) GetChanges() int64 {
+	if x != nil {
+		return x.Changes
+	}
+	return 0
+}
+
+// Ответ со свечами, по одной на интервал начиная с первого известного курса
+type RateCandlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Interval      HistoryInterval        `protobuf:"varint,3,opt,name=interval,proto3,enum=exchange.v1.HistoryInterval" json:"interval,omitempty"`
+	Candles       []*RateCandle          `protobuf:"bytes,4,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateCandlesResponse) Reset() {
+	*x = RateCandlesResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateCandlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateCandlesResponse) ProtoMessage() {}
+
+func (x *RateCandlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateCandlesResponse.ProtoReflect.Descriptor instead.
+func (*RateCandlesResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RateCandlesResponse) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *RateCandlesResponse) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *RateCandlesResponse) GetInterval() HistoryInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return HistoryInterval_HISTORY_INTERVAL_UNSPECIFIED
+}
+
+func (x *RateCandlesResponse) GetCandles() []*RateCandle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+// Ручная фиксация курса валютной пары
+type RateOverride struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RateOverrideId string                 `protobuf:"bytes,1,opt,name=rate_override_id,json=rateOverrideId,proto3" json:"rate_override_id,omitempty"`
+	FromCurrency   string                 `protobuf:"bytes,2,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency     string                 `protobuf:"bytes,3,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Rate           *Decimal               `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                        // причина фиксации
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // момент, до которого курс зафиксирован
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RateOverride) Reset() {
+	*x = RateOverride{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateOverride) ProtoMessage() {}
+
+func (x *RateOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateOverride.ProtoReflect.Descriptor instead.
+func (*RateOverride) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RateOverride) GetRateOverrideId() string {
+	if x != nil {
+		return x.RateOverrideId
+	}
+	return ""
+}
+
+func (x *RateOverride) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *RateOverride) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *RateOverride) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *RateOverride) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RateOverride) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *RateOverride) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RateOverride) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Запрос ручной фиксации курса; заменяет действующую фиксацию пары
+type SetRateOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Rate          *Decimal               `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // должен быть в будущем
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRateOverrideRequest) Reset() {
+	*x = SetRateOverrideRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRateOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRateOverrideRequest) ProtoMessage() {}
+
+func (x *SetRateOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRateOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetRateOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SetRateOverrideRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *SetRateOverrideRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *SetRateOverrideRequest) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *SetRateOverrideRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SetRateOverrideRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Запрос снятия ручной фиксации курса
+type DeleteRateOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRateOverrideRequest) Reset() {
+	*x = DeleteRateOverrideRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRateOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRateOverrideRequest) ProtoMessage() {}
+
+func (x *DeleteRateOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRateOverrideRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRateOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteRateOverrideRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *DeleteRateOverrideRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+// Ответ на снятие ручной фиксации курса
+type DeleteRateOverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"` // false, если фиксации для пары не было
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRateOverrideResponse) Reset() {
+	*x = DeleteRateOverrideResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRateOverrideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRateOverrideResponse) ProtoMessage() {}
+
+func (x *DeleteRateOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRateOverrideResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRateOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteRateOverrideResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Запрос списка действующих ручных фиксаций
+type ListRateOverridesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRateOverridesRequest) Reset() {
+	*x = ListRateOverridesRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRateOverridesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRateOverridesRequest) ProtoMessage() {}
+
+func (x *ListRateOverridesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRateOverridesRequest.ProtoReflect.Descriptor instead.
+func (*ListRateOverridesRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{32}
+}
+
+// Ответ со списком действующих ручных фиксаций, упорядоченным по исходной и целевой валюте
+type ListRateOverridesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Overrides     []*RateOverride        `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRateOverridesResponse) Reset() {
+	*x = ListRateOverridesResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRateOverridesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRateOverridesResponse) ProtoMessage() {}
+
+func (x *ListRateOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRateOverridesResponse.ProtoReflect.Descriptor instead.
+func (*ListRateOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListRateOverridesResponse) GetOverrides() []*RateOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+var File_exchange_v1_exchange_proto protoreflect.FileDescriptor
+
+const file_exchange_v1_exchange_proto_rawDesc = "" +
+	"\n" +
+	"\x1aexchange/v1/exchange.proto\x12\vexchange.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"\x84\x01\n" +
+	"\vRateRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12/\n" +
+	"\x05as_of\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\x82\x03\n" +
+	"\fRateResponse\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12\x16\n" +
+	"\x04rate\x18\x03 \x01(\x01B\x02\x18\x01R\x04rate\x12/\n" +
+	"\x05as_of\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x127\n" +
+	"\frate_decimal\x18\x05 \x01(\v2\x14.exchange.v1.DecimalR\vrateDecimal\x12\x18\n" +
+	"\aderived\x18\x06 \x01(\bR\aderived\x12(\n" +
+	"\x04legs\x18\a \x03(\v2\x14.exchange.v1.RateLegR\x04legs\x12\x1a\n" +
+	"\boverride\x18\b \x01(\bR\boverride\x12J\n" +
+	"\x13override_expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x11overrideExpiresAt\"\xd0\x01\n" +
+	"\aRateLeg\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12(\n" +
+	"\x04rate\x18\x03 \x01(\v2\x14.exchange.v1.DecimalR\x04rate\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\boverride\x18\x05 \x01(\bR\boverride\"\xaf\x01\n" +
+	"\x15ConversionPathRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x125\n" +
+	"\bstrategy\x18\x03 \x01(\x0e2\x19.exchange.v1.PathStrategyR\bstrategy\x12\x19\n" +
+	"\bmax_hops\x18\x04 \x01(\rR\amaxHops\"\xe9\x01\n" +
+	"\x16ConversionPathResponse\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12(\n" +
+	"\x04rate\x18\x03 \x01(\v2\x14.exchange.v1.DecimalR\x04rate\x12(\n" +
+	"\x04legs\x18\x04 \x03(\v2\x14.exchange.v1.RateLegR\x04legs\x125\n" +
+	"\bstrategy\x18\x05 \x01(\x0e2\x19.exchange.v1.PathStrategyR\bstrategy\"K\n" +
+	"\aDecimal\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05units\x18\x02 \x01(\x03R\x05units\x12\x14\n" +
+	"\x05nanos\x18\x03 \x01(\x05R\x05nanos\"\xc5\x02\n" +
+	"\fExchangeRate\x12(\n" +
+	"\x10exchange_rate_id\x18\x01 \x01(\tR\x0eexchangeRateId\x12#\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x03 \x01(\tR\n" +
+	"toCurrency\x12\x16\n" +
+	"\x04rate\x18\x04 \x01(\x01B\x02\x18\x01R\x04rate\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
+	"\frate_decimal\x18\a \x01(\v2\x14.exchange.v1.DecimalR\vrateDecimal\"\xaa\x01\n" +
+	"\x11UpsertRateRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12\x16\n" +
+	"\x04rate\x18\x03 \x01(\x01B\x02\x18\x01R\x04rate\x127\n" +
+	"\frate_decimal\x18\x04 \x01(\v2\x14.exchange.v1.DecimalR\vrateDecimal\"J\n" +
+	"\x12UpsertRatesRequest\x124\n" +
+	"\x05rates\x18\x01 \x03(\v2\x1e.exchange.v1.UpsertRateRequestR\x05rates\"F\n" +
+	"\x13UpsertRatesResponse\x12/\n" +
+	"\x05rates\x18\x01 \x03(\v2\x19.exchange.v1.ExchangeRateR\x05rates\"Y\n" +
+	"\x11DeleteRateRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\".\n" +
+	"\x12DeleteRateResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\xfb\x01\n" +
+	"\x14ConvertAmountRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12,\n" +
+	"\x06amount\x18\x03 \x01(\v2\x14.exchange.v1.DecimalR\x06amount\x12>\n" +
+	"\rrounding_mode\x18\x04 \x01(\x0e2\x19.exchange.v1.RoundingModeR\froundingMode\x12/\n" +
+	"\x05as_of\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\xa2\x03\n" +
+	"\x15ConvertAmountResponse\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12,\n" +
+	"\x06amount\x18\x03 \x01(\v2\x14.exchange.v1.DecimalR\x06amount\x12?\n" +
+	"\x10converted_amount\x18\x04 \x01(\v2\x14.exchange.v1.DecimalR\x0fconvertedAmount\x12(\n" +
+	"\x04rate\x18\x05 \x01(\v2\x14.exchange.v1.DecimalR\x04rate\x12\x1f\n" +
+	"\vminor_units\x18\x06 \x01(\x05R\n" +
+	"minorUnits\x12>\n" +
+	"\rrounding_mode\x18\a \x01(\x0e2\x19.exchange.v1.RoundingModeR\froundingMode\x12\x18\n" +
+	"\aderived\x18\b \x01(\bR\aderived\x12/\n" +
+	"\x05as_of\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\xa8\x01\n" +
+	"\bCurrency\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12!\n" +
+	"\fnumeric_code\x18\x02 \x01(\x05R\vnumericCode\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x1f\n" +
+	"\vminor_units\x18\x05 \x01(\x05R\n" +
+	"minorUnits\x12\x18\n" +
+	"\aenabled\x18\x06 \x01(\bR\aenabled\"M\n" +
+	"\x15ListCurrenciesRequest\x124\n" +
+	"\aenabled\x18\x01 \x01(\v2\x1a.google.protobuf.BoolValueR\aenabled\"O\n" +
+	"\x16ListCurrenciesResponse\x125\n" +
+	"\n" +
+	"currencies\x18\x01 \x03(\v2\x15.exchange.v1.CurrencyR\n" +
+	"currencies\"^\n" +
+	"\x10ListRatesRequest\x12#\n" +
+	"\rbase_currency\x18\x01 \x01(\tR\fbaseCurrency\x12%\n" +
+	"\x0equote_currency\x18\x02 \x01(\tR\rquoteCurrency\"D\n" +
+	"\x11ListRatesResponse\x12/\n" +
+	"\x05rates\x18\x01 \x03(\v2\x19.exchange.v1.ExchangeRateR\x05rates\"T\n" +
+	"\fCurrencyPair\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\"H\n" +
+	"\x15SubscribeRatesRequest\x12/\n" +
+	"\x05pairs\x18\x01 \x03(\v2\x19.exchange.v1.CurrencyPairR\x05pairs\"\x9e\x01\n" +
+	"\x16SubscribeRatesResponse\x12\x1a\n" +
+	"\bsnapshot\x18\x01 \x01(\bR\bsnapshot\x123\n" +
+	"\aupdated\x18\x02 \x03(\v2\x19.exchange.v1.ExchangeRateR\aupdated\x123\n" +
+	"\adeleted\x18\x03 \x03(\v2\x19.exchange.v1.CurrencyPairR\adeleted\"\xf4\x01\n" +
+	"\x12RateHistoryRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x128\n" +
+	"\binterval\x18\x05 \x01(\x0e2\x1c.exchange.v1.HistoryIntervalR\binterval\"e\n" +
+	"\tRatePoint\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12(\n" +
+	"\x04rate\x18\x02 \x01(\v2\x14.exchange.v1.DecimalR\x04rate\"\xc5\x01\n" +
+	"\x13RateHistoryResponse\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x128\n" +
+	"\binterval\x18\x03 \x01(\x0e2\x1c.exchange.v1.HistoryIntervalR\binterval\x12.\n" +
+	"\x06points\x18\x04 \x03(\v2\x16.exchange.v1.RatePointR\x06points\"\xf4\x01\n" +
+	"\x12RateCandlesRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x128\n" +
+	"\binterval\x18\x05 \x01(\x0e2\x1c.exchange.v1.HistoryIntervalR\binterval\"\xfe\x01\n" +
+	"\n" +
+	"RateCandle\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12(\n" +
+	"\x04open\x18\x02 \x01(\v2\x14.exchange.v1.DecimalR\x04open\x12(\n" +
+	"\x04high\x18\x03 \x01(\v2\x14.exchange.v1.DecimalR\x04high\x12&\n" +
+	"\x03low\x18\x04 \x01(\v2\x14.exchange.v1.DecimalR\x03low\x12*\n" +
+	"\x05close\x18\x05 \x01(\v2\x14.exchange.v1.DecimalR\x05close\x12\x18\n" +
+	"\achanges\x18\x06 \x01(\x03R\achanges\"\xc8\x01\n" +
+	"\x13RateCandlesResponse\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x128\n" +
+	"\binterval\x18\x03 \x01(\x0e2\x1c.exchange.v1.HistoryIntervalR\binterval\x121\n" +
+	"\acandles\x18\x04 \x03(\v2\x17.exchange.v1.RateCandleR\acandles\"\xf1\x02\n" +
+	"\fRateOverride\x12(\n" +
+	"\x10rate_override_id\x18\x01 \x01(\tR\x0erateOverrideId\x12#\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x03 \x01(\tR\n" +
+	"toCurrency\x12(\n" +
+	"\x04rate\x18\x04 \x01(\v2\x14.exchange.v1.DecimalR\x04rate\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdb\x01\n" +
+	"\x16SetRateOverrideRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12(\n" +
+	"\x04rate\x18\x03 \x01(\v2\x14.exchange.v1.DecimalR\x04rate\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"a\n" +
+	"\x19DeleteRateOverrideRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\"6\n" +
+	"\x1aDeleteRateOverrideResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\x1a\n" +
+	"\x18ListRateOverridesRequest\"T\n" +
+	"\x19ListRateOverridesResponse\x127\n" +
+	"\toverrides\x18\x01 \x03(\v2\x19.exchange.v1.RateOverrideR\toverrides*h\n" +
+	"\fPathStrategy\x12\x1d\n" +
+	"\x19PATH_STRATEGY_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PATH_STRATEGY_FEWEST_HOPS\x10\x01\x12\x1a\n" +
+	"\x16PATH_STRATEGY_FRESHEST\x10\x02*}\n" +
+	"\fRoundingMode\x12\x1d\n" +
+	"\x19ROUNDING_MODE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ROUNDING_MODE_HALF_EVEN\x10\x01\x12\x19\n" +
+	"\x15ROUNDING_MODE_HALF_UP\x10\x02\x12\x16\n" +
+	"\x12ROUNDING_MODE_DOWN\x10\x03*\x85\x01\n" +
+	"\x0fHistoryInterval\x12 \n" +
+	"\x1cHISTORY_INTERVAL_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17HISTORY_INTERVAL_MINUTE\x10\x01\x12\x19\n" +
+	"\x15HISTORY_INTERVAL_HOUR\x10\x02\x12\x18\n" +
+	"\x14HISTORY_INTERVAL_DAY\x10\x032\xb2\x05\n" +
+	"\vRateService\x12>\n" +
+	"\aGetRate\x12\x18.exchange.v1.RateRequest\x1a\x19.exchange.v1.RateResponse\x12]\n" +
+	"\x12FindConversionPath\x12\".exchange.v1.ConversionPathRequest\x1a#.exchange.v1.ConversionPathResponse\x12V\n" +
+	"\rConvertAmount\x12!.exchange.v1.ConvertAmountRequest\x1a\".exchange.v1.ConvertAmountResponse\x12Y\n" +
+	"\x0eListCurrencies\x12\".exchange.v1.ListCurrenciesRequest\x1a#.exchange.v1.ListCurrenciesResponse\x12J\n" +
+	"\tListRates\x12\x1d.exchange.v1.ListRatesRequest\x1a\x1e.exchange.v1.ListRatesResponse\x12[\n" +
+	"\x0eSubscribeRates\x12\".exchange.v1.SubscribeRatesRequest\x1a#.exchange.v1.SubscribeRatesResponse0\x01\x12S\n" +
+	"\x0eGetRateHistory\x12\x1f.exchange.v1.RateHistoryRequest\x1a .exchange.v1.RateHistoryResponse\x12S\n" +
+	"\x0eGetRateCandles\x12\x1f.exchange.v1.RateCandlesRequest\x1a .exchange.v1.RateCandlesResponse2\x96\x04\n" +
+	"\fAdminService\x12G\n" +
+	"\n" +
+	"UpsertRate\x12\x1e.exchange.v1.UpsertRateRequest\x1a\x19.exchange.v1.ExchangeRate\x12P\n" +
+	"\vUpsertRates\x12\x1f.exchange.v1.UpsertRatesRequest\x1a .exchange.v1.UpsertRatesResponse\x12M\n" +
+	"\n" +
+	"DeleteRate\x12\x1e.exchange.v1.DeleteRateRequest\x1a\x1f.exchange.v1.DeleteRateResponse\x12Q\n" +
+	"\x0fSetRateOverride\x12#.exchange.v1.SetRateOverrideRequest\x1a\x19.exchange.v1.RateOverride\x12e\n" +
+	"\x12DeleteRateOverride\x12&.exchange.v1.DeleteRateOverrideRequest\x1a'.exchange.v1.DeleteRateOverrideResponse\x12b\n" +
+	"\x11ListRateOverrides\x12%.exchange.v1.ListRateOverridesRequest\x1a&.exchange.v1.ListRateOverridesResponseBGZEgithub.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1;exchangev1b\x06proto3"
+
+var (
+	file_exchange_v1_exchange_proto_rawDescOnce sync.Once
+	file_exchange_v1_exchange_proto_rawDescData []byte
+)
+
+func file_exchange_v1_exchange_proto_rawDescGZIP() []byte {
+	file_exchange_v1_exchange_proto_rawDescOnce.Do(func() {
+		file_exchange_v1_exchange_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_exchange_v1_exchange_proto_rawDesc), len(file_exchange_v1_exchange_proto_rawDesc)))
+	})
+	return file_exchange_v1_exchange_proto_rawDescData
+}
+
+var file_exchange_v1_exchange_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_exchange_v1_exchange_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_exchange_v1_exchange_proto_goTypes = []any{
+	(PathStrategy)(0),                  // 0: exchange.v1.PathStrategy
+	(RoundingMode)(0),                  // 1: exchange.v1.RoundingMode
+	(HistoryInterval)(0),               // 2: exchange.v1.HistoryInterval
+	(*RateRequest)(nil),                // 3: exchange.v1.RateRequest
+	(*RateResponse)(nil),               // 4: exchange.v1.RateResponse
+	(*RateLeg)(nil),                    // 5: exchange.v1.RateLeg
+	(*ConversionPathRequest)(nil),      // 6: exchange.v1.ConversionPathRequest
+	(*ConversionPathResponse)(nil),     // 7: exchange.v1.ConversionPathResponse
+	(*Decimal)(nil),                    // 8: exchange.v1.Decimal
+	(*ExchangeRate)(nil),               // 9: exchange.v1.ExchangeRate
+	(*UpsertRateRequest)(nil),          // 10: exchange.v1.UpsertRateRequest
+	(*UpsertRatesRequest)(nil),         // 11: exchange.v1.UpsertRatesRequest
+	(*UpsertRatesResponse)(nil),        // 12: exchange.v1.UpsertRatesResponse
+	(*DeleteRateRequest)(nil),          // 13: exchange.v1.DeleteRateRequest
+	(*DeleteRateResponse)(nil),         // 14: exchange.v1.DeleteRateResponse
+	(*ConvertAmountRequest)(nil),       // 15: exchange.v1.ConvertAmountRequest
+	(*ConvertAmountResponse)(nil),      // 16: exchange.v1.ConvertAmountResponse
+	(*Currency)(nil),                   // 17: exchange.v1.Currency
+	(*ListCurrenciesRequest)(nil),      // 18: exchange.v1.ListCurrenciesRequest
+	(*ListCurrenciesResponse)(nil),     // 19: exchange.v1.ListCurrenciesResponse
+	(*ListRatesRequest)(nil),           // 20: exchange.v1.ListRatesRequest
+	(*ListRatesResponse)(nil),          // 21: exchange.v1.ListRatesResponse
+	(*CurrencyPair)(nil),               // 22: exchange.v1.CurrencyPair
+	(*SubscribeRatesRequest)(nil),      // 23: exchange.v1.SubscribeRatesRequest
+	(*SubscribeRatesResponse)(nil),     // 24: exchange.v1.SubscribeRatesResponse
+	(*RateHistoryRequest)(nil),         // 25: exchange.v1.RateHistoryRequest
+	(*RatePoint)(nil),                  // 26: exchange.v1.RatePoint
+	(*RateHistoryResponse)(nil),        // 27: exchange.v1.RateHistoryResponse
+	(*RateCandlesRequest)(nil),         // 28: exchange.v1.RateCandlesRequest
+	(*RateCandle)(nil),                 // 29: exchange.v1.RateCandle
+	(*RateCandlesResponse)(nil),        // 30: exchange.v1.RateCandlesResponse
+	(*RateOverride)(nil),               // 31: exchange.v1.RateOverride
+	(*SetRateOverrideRequest)(nil),     // 32: exchange.v1.SetRateOverrideRequest
+	(*DeleteRateOverrideRequest)(nil),  // 33: exchange.v1.DeleteRateOverrideRequest
+	(*DeleteRateOverrideResponse)(nil), // 34: exchange.v1.DeleteRateOverrideResponse
+	(*ListRateOverridesRequest)(nil),   // 35: exchange.v1.ListRateOverridesRequest
+	(*ListRateOverridesResponse)(nil),  // 36: exchange.v1.ListRateOverridesResponse
+	(*timestamppb.Timestamp)(nil),      // 37: google.protobuf.Timestamp
+	(*wrapperspb.BoolValue)(nil),       // 38: google.protobuf.BoolValue
+}
+var file_exchange_v1_exchange_proto_depIdxs = []int32{
+	37, // 0: exchange.v1.RateRequest.as_of:type_name -> google.protobuf.Timestamp
+	37, // 1: exchange.v1.RateResponse.as_of:type_name -> google.protobuf.Timestamp
+	8,  // 2: exchange.v1.RateResponse.rate_decimal:type_name -> exchange.v1.Decimal
+	5,  // 3: exchange.v1.RateResponse.legs:type_name -> exchange.v1.RateLeg
+	37, // 4: exchange.v1.RateResponse.override_expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 5: exchange.v1.RateLeg.rate:type_name -> exchange.v1.Decimal
+	37, // 6: exchange.v1.RateLeg.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: exchange.v1.ConversionPathRequest.strategy:type_name -> exchange.v1.PathStrategy
+	8,  // 8: exchange.v1.ConversionPathResponse.rate:type_name -> exchange.v1.Decimal
+	5,  // 9: exchange.v1.ConversionPathResponse.legs:type_name -> exchange.v1.RateLeg
+	0,  // 10: exchange.v1.ConversionPathResponse.strategy:type_name -> exchange.v1.PathStrategy
+	37, // 11: exchange.v1.ExchangeRate.created_at:type_name -> google.protobuf.Timestamp
+	37, // 12: exchange.v1.ExchangeRate.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 13: exchange.v1.ExchangeRate.rate_decimal:type_name -> exchange.v1.Decimal
+	8,  // 14: exchange.v1.UpsertRateRequest.rate_decimal:type_name -> exchange.v1.Decimal
+	10, // 15: exchange.v1.UpsertRatesRequest.rates:type_name -> exchange.v1.UpsertRateRequest
+	9,  // 16: exchange.v1.UpsertRatesResponse.rates:type_name -> exchange.v1.ExchangeRate
+	8,  // 17: exchange.v1.ConvertAmountRequest.amount:type_name -> exchange.v1.Decimal
+	1,  // 18: exchange.v1.ConvertAmountRequest.rounding_mode:type_name -> exchange.v1.RoundingMode
+	37, // 19: exchange.v1.ConvertAmountRequest.as_of:type_name -> google.protobuf.Timestamp
+	8,  // 20: exchange.v1.ConvertAmountResponse.amount:type_name -> exchange.v1.Decimal
+	8,  // 21: exchange.v1.ConvertAmountResponse.converted_amount:type_name -> exchange.v1.Decimal
+	8,  // 22: exchange.v1.ConvertAmountResponse.rate:type_name -> exchange.v1.Decimal
+	1,  // 23: exchange.v1.ConvertAmountResponse.rounding_mode:type_name -> exchange.v1.RoundingMode
+	37, // 24: exchange.v1.ConvertAmountResponse.as_of:type_name -> google.protobuf.Timestamp
+	38, // 25: exchange.v1.ListCurrenciesRequest.enabled:type_name -> google.protobuf.BoolValue
+	17, // 26: exchange.v1.ListCurrenciesResponse.currencies:type_name -> exchange.v1.Currency
+	9,  // 27: exchange.v1.ListRatesResponse.rates:type_name -> exchange.v1.ExchangeRate
+	22, // 28: exchange.v1.SubscribeRatesRequest.pairs:type_name -> exchange.v1.CurrencyPair
+	9,  // 29: exchange.v1.SubscribeRatesResponse.updated:type_name -> exchange.v1.ExchangeRate
+	22, // 30: exchange.v1.SubscribeRatesResponse.deleted:type_name -> exchange.v1.CurrencyPair
+	37, // 31: exchange.v1.RateHistoryRequest.start:type_name -> google.protobuf.Timestamp
+	37, // 32: exchange.v1.RateHistoryRequest.end:type_name -> google.protobuf.Timestamp
+	2,  // 33: exchange.v1.RateHistoryRequest.interval:type_name -> exchange.v1.HistoryInterval
+	37, // 34: exchange.v1.RatePoint.time:type_name -> google.protobuf.Timestamp
+	8,  // 35: exchange.v1.RatePoint.rate:type_name -> exchange.v1.Decimal
+	2,  // 36: exchange.v1.RateHistoryResponse.interval:type_name -> exchange.v1.HistoryInterval
+	26, // 37: exchange.v1.RateHistoryResponse.points:type_name -> exchange.v1.RatePoint
+	37, // 38: exchange.v1.RateCandlesRequest.start:type_name -> google.protobuf.Timestamp
+	37, // 39: exchange.v1.RateCandlesRequest.end:type_name -> google.protobuf.Timestamp
+	2,  // 40: exchange.v1.RateCandlesRequest.interval:type_name -> exchange.v1.HistoryInterval
+	37, // 41: exchange.v1.RateCandle.time:type_name -> google.protobuf.Timestamp
+	8,  // 42: exchange.v1.RateCandle.open:type_name -> exchange.v1.Decimal
+	8,  // 43: exchange.v1.RateCandle.high:type_name -> exchange.v1.Decimal
+	8,  // 44: exchange.v1.RateCandle.low:type_name -> exchange.v1.Decimal
+	8,  // 45: exchange.v1.RateCandle.close:type_name -> exchange.v1.Decimal
+	2,  // 46: exchange.v1.RateCandlesResponse.interval:type_name -> exchange.v1.HistoryInterval
+	29, // 47: exchange.v1.RateCandlesResponse.candles:type_name -> exchange.v1.RateCandle
+	8,  // 48: exchange.v1.RateOverride.rate:type_name -> exchange.v1.Decimal
+	37, // 49: exchange.v1.RateOverride.expires_at:type_name -> google.protobuf.Timestamp
+	37, // 50: exchange.v1.RateOverride.created_at:type_name -> google.protobuf.Timestamp
+	37, // 51: exchange.v1.RateOverride.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 52: exchange.v1.SetRateOverrideRequest.rate:type_name -> exchange.v1.Decimal
+	37, // 53: exchange.v1.SetRateOverrideRequest.expires_at:type_name -> google.protobuf.Timestamp
+	31, // 54: exchange.v1.ListRateOverridesResponse.overrides:type_name -> exchange.v1.RateOverride
+	3,  // 55: exchange.v1.RateService.GetRate:input_type -> exchange.v1.RateRequest
+	6,  // 56: exchange.v1.RateService.FindConversionPath:input_type -> exchange.v1.ConversionPathRequest
+	15, // 57: exchange.v1.RateService.ConvertAmount:input_type -> exchange.v1.ConvertAmountRequest
+	18, // 58: exchange.v1.RateService.ListCurrencies:input_type -> exchange.v1.ListCurrenciesRequest
+	20, // 59: exchange.v1.RateService.ListRates:input_type -> exchange.v1.ListRatesRequest
+	23, // 60: exchange.v1.RateService.SubscribeRates:input_type -> exchange.v1.SubscribeRatesRequest
+	25, // 61: exchange.v1.RateService.GetRateHistory:input_type -> exchange.v1.RateHistoryRequest
+	28, // 62: exchange.v1.RateService.GetRateCandles:input_type -> exchange.v1.RateCandlesRequest
+	10, // 63: exchange.v1.AdminService.UpsertRate:input_type -> exchange.v1.UpsertRateRequest
+	11, // 64: exchange.v1.AdminService.UpsertRates:input_type -> exchange.v1.UpsertRatesRequest
+	13, // 65: exchange.v1.AdminService.DeleteRate:input_type -> exchange.v1.DeleteRateRequest
+	32, // 66: exchange.v1.AdminService.SetRateOverride:input_type -> exchange.v1.SetRateOverrideRequest
+	33, // 67: exchange.v1.AdminService.DeleteRateOverride:input_type -> exchange.v1.DeleteRateOverrideRequest
+	35, // 68: exchange.v1.AdminService.ListRateOverrides:input_type -> exchange.v1.ListRateOverridesRequest
+	4,  // 69: exchange.v1.RateService.GetRate:output_type -> exchange.v1.RateResponse
+	7,  // 70: exchange.v1.RateService.FindConversionPath:output_type -> exchange.v1.ConversionPathResponse
+	16, // 71: exchange.v1.RateService.ConvertAmount:output_type -> exchange.v1.ConvertAmountResponse
+	19, // 72: exchange.v1.RateService.ListCurrencies:output_type -> exchange.v1.ListCurrenciesResponse
+	21, // 73: exchange.v1.RateService.ListRates:output_type -> exchange.v1.ListRatesResponse
+	24, // 74: exchange.v1.RateService.SubscribeRates:output_type -> exchange.v1.SubscribeRatesResponse
+	27, // 75: exchange.v1.RateService.GetRateHistory:output_type -> exchange.v1.RateHistoryResponse
+	30, // 76: exchange.v1.RateService.GetRateCandles:output_type -> exchange.v1.RateCandlesResponse
+	9,  // 77: exchange.v1.AdminService.UpsertRate:output_type -> exchange.v1.ExchangeRate
+	12, // 78: exchange.v1.AdminService.UpsertRates:output_type -> exchange.v1.UpsertRatesResponse
+	14, // 79: exchange.v1.AdminService.DeleteRate:output_type -> exchange.v1.DeleteRateResponse
+	31, // 80: exchange.v1.AdminService.SetRateOverride:output_type -> exchange.v1.RateOverride
+	34, // 81: exchange.v1.AdminService.DeleteRateOverride:output_type -> exchange.v1.DeleteRateOverrideResponse
+	36, // 82: exchange.v1.AdminService.ListRateOverrides:output_type -> exchange.v1.ListRateOverridesResponse
+	69, // [69:83] is the sub-list for method output_type
+	55, // [55:69] is the sub-list for method input_type
+	55, // [55:55] is the sub-list for extension type_name
+	55, // [55:55] is the sub-list for extension extendee
+	0,  // [0:55] is the sub-list for field type_name
+}
+
+func init() { file_exchange_v1_exchange_proto_init() }
+func file_exchange_v1_exchange_proto_init() {
+	if File_exchange_v1_exchange_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_exchange_v1_exchange_proto_rawDesc), len(file_exchange_v1_exchange_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   34,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_exchange_v1_exchange_proto_goTypes,
+		DependencyIndexes: file_exchange_v1_exchange_proto_depIdxs,
+		EnumInfos:         file_exchange_v1_exchange_proto_enumTypes,
+		MessageInfos:      file_exchange_v1_exchange_proto_msgTypes,
+	}.Build()
+	File_exchange_v1_exchange_proto = out.File
+	file_exchange_v1_exchange_proto_goTypes = nil
+	file_exchange_v1_exchange_proto_depIdxs = nil
+}
@@ -0,0 +1,729 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: exchange/v1/exchange.proto
+
+package exchangev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RateService_GetRate_FullMethodName            = "/exchange.v1.RateService/GetRate"
+	RateService_FindConversionPath_FullMethodName = "/exchange.v1.RateService/FindConversionPath"
+	RateService_ConvertAmount_FullMethodName      = "/exchange.v1.RateService/ConvertAmount"
+	RateService_ListCurrencies_FullMethodName     = "/exchange.v1.RateService/ListCurrencies"
+	RateService_ListRates_FullMethodName          = "/exchange.v1.RateService/ListRates"
+	RateService_SubscribeRates_FullMethodName     = "/exchange.v1.RateService/SubscribeRates"
+	RateService_GetRateHistory_FullMethodName     = "/exchange.v1.RateService/GetRateHistory"
+	RateService_GetRateCandles_FullMethodName     = "/exchange.v1.RateService/GetRateCandles"
+)
+
+// RateServiceClient is the client API for RateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Расширенный API сервиса курсов валют.
+// Базовый контракт ExchangeService описан в репозитории proto-exchange.
+type RateServiceClient interface {
+	// Получение курса обмена для валютной пары на заданный момент времени.
+	// Ручные фиксации учитываются только для текущего курса: курс на прошлый момент (as_of)
+	// берётся из истории хранимых курсов, в которую фиксации не попадают
+	GetRate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error)
+	// Поиск пути конвертации между двумя валютами по графу хранимых курсов
+	FindConversionPath(ctx context.Context, in *ConversionPathRequest, opts ...grpc.CallOption) (*ConversionPathResponse, error)
+	// Конвертация суммы с округлением до минимальных единиц целевой валюты
+	ConvertAmount(ctx context.Context, in *ConvertAmountRequest, opts ...grpc.CallOption) (*ConvertAmountResponse, error)
+	// Получение справочника валют
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+	// Получение списка хранимых курсов валютных пар
+	ListRates(ctx context.Context, in *ListRatesRequest, opts ...grpc.CallOption) (*ListRatesResponse, error)
+	// Подписка на изменения курсов: первым сообщением приходит снимок текущих курсов,
+	// затем — изменения запрошенных пар
+	SubscribeRates(ctx context.Context, in *SubscribeRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeRatesResponse], error)
+	// Получение истории курса валютной пары с разбиением на интервалы
+	GetRateHistory(ctx context.Context, in *RateHistoryRequest, opts ...grpc.CallOption) (*RateHistoryResponse, error)
+	// Получение свечей (open/high/low/close) курса валютной пары по интервалам
+	GetRateCandles(ctx context.Context, in *RateCandlesRequest, opts ...grpc.CallOption) (*RateCandlesResponse, error)
+}
+
+type rateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRateServiceClient(cc grpc.ClientConnInterface) RateServiceClient {
+	return &rateServiceClient{cc}
+}
+
+func (c *rateServiceClient) GetRate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateResponse)
+	err := c.cc.Invoke(ctx, RateService_GetRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) FindConversionPath(ctx context.Context, in *ConversionPathRequest, opts ...grpc.CallOption) (*ConversionPathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConversionPathResponse)
+	err := c.cc.Invoke(ctx, RateService_FindConversionPath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) ConvertAmount(ctx context.Context, in *ConvertAmountRequest, opts ...grpc.CallOption) (*ConvertAmountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertAmountResponse)
+	err := c.cc.Invoke(ctx, RateService_ConvertAmount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, RateService_ListCurrencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) ListRates(ctx context.Context, in *ListRatesRequest, opts ...grpc.CallOption) (*ListRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRatesResponse)
+	err := c.cc.Invoke(ctx, RateService_ListRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) SubscribeRates(ctx context.Context, in *SubscribeRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeRatesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RateService_ServiceDesc.Streams[0], RateService_SubscribeRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRatesRequest, SubscribeRatesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RateService_SubscribeRatesClient = grpc.ServerStreamingClient[SubscribeRatesResponse]
+
+func (c *rateServiceClient) GetRateHistory(ctx context.Context, in *RateHistoryRequest, opts ...grpc.CallOption) (*RateHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateHistoryResponse)
+	err := c.cc.Invoke(ctx, RateService_GetRateHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rateServiceClient) GetRateCandles(ctx context.Context, in *RateCandlesRequest, opts ...grpc.CallOption) (*RateCandlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateCandlesResponse)
+	err := c.cc.Invoke(ctx, RateService_GetRateCandles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RateServiceServer is the server API for RateService service.
+// All implementations must embed UnimplementedRateServiceServer
+// for forward compatibility.
+//
+// Расширенный API сервиса курсов валют.
+// Базовый контракт ExchangeService описан в репозитории proto-exchange.
+type RateServiceServer interface {
+	// Получение курса обмена для валютной пары на заданный момент времени.
+	// Ручные фиксации учитываются только для текущего курса: курс на прошлый момент (as_of)
+	// берётся из истории хранимых курсов, в которую фиксации не попадают
+	GetRate(context.Context, *RateRequest) (*RateResponse, error)
+	// Поиск пути конвертации между двумя валютами по графу хранимых курсов
+	FindConversionPath(context.Context, *ConversionPathRequest) (*ConversionPathResponse, error)
+	// Конвертация суммы с округлением до минимальных единиц целевой валюты
+	ConvertAmount(context.Context, *ConvertAmountRequest) (*ConvertAmountResponse, error)
+	// Получение справочника валют
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
+	// Получение списка хранимых курсов валютных пар
+	ListRates(context.Context, *ListRatesRequest) (*ListRatesResponse, error)
+	// Подписка на изменения курсов: первым сообщением приходит снимок текущих курсов,
+	// затем — изменения запрошенных пар
+	SubscribeRates(*SubscribeRatesRequest, grpc.ServerStreamingServer[SubscribeRatesResponse]) error
+	// Получение истории курса валютной пары с разбиением на интервалы
+	GetRateHistory(context.Context, *RateHistoryRequest) (*RateHistoryResponse, error)
+	// Получение свечей (open/high/low/close) курса валютной пары по интервалам
+	GetRateCandles(context.Context, *RateCandlesRequest) (*RateCandlesResponse, error)
+	mustEmbedUnimplementedRateServiceServer()
+}
+
+// UnimplementedRateServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRateServiceServer struct{}
+
+func (UnimplementedRateServiceServer) GetRate(context.Context, *RateRequest) (*RateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRate not implemented")
+}
+func (UnimplementedRateServiceServer) FindConversionPath(context.Context, *ConversionPathRequest) (*ConversionPathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindConversionPath not implemented")
+}
+func (UnimplementedRateServiceServer) ConvertAmount(context.Context, *ConvertAmountRequest) (*ConvertAmountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertAmount not implemented")
+}
+func (UnimplementedRateServiceServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedRateServiceServer) ListRates(context.Context, *ListRatesRequest) (*ListRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRates not implemented")
+}
+func (UnimplementedRateServiceServer) SubscribeRates(*SubscribeRatesRequest, grpc.ServerStreamingServer[SubscribeRatesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeRates not implemented")
+}
+func (UnimplementedRateServiceServer) GetRateHistory(context.Context, *RateHistoryRequest) (*RateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateHistory not implemented")
+}
+func (UnimplementedRateServiceServer) GetRateCandles(context.Context, *RateCandlesRequest) (*RateCandlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateCandles not implemented")
+}
+func (UnimplementedRateServiceServer) mustEmbedUnimplementedRateServiceServer() {}
+func (UnimplementedRateServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeRateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RateServiceServer will
+// result in compilation errors.
+type UnsafeRateServiceServer interface {
+	mustEmbedUnimplementedRateServiceServer()
+}
+
+func RegisterRateServiceServer(s grpc.ServiceRegistrar, srv RateServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RateService_ServiceDesc, srv)
+}
+
+func _RateService_GetRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).GetRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_GetRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).GetRate(ctx, req.(*RateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_FindConversionPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversionPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).FindConversionPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_FindConversionPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).FindConversionPath(ctx, req.(*ConversionPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_ConvertAmount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertAmountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).ConvertAmount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_ConvertAmount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).ConvertAmount(ctx, req.(*ConvertAmountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_ListCurrencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_ListRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).ListRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_ListRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).ListRates(ctx, req.(*ListRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_SubscribeRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RateServiceServer).SubscribeRates(m, &grpc.GenericServerStream[SubscribeRatesRequest, SubscribeRatesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RateService_SubscribeRatesServer = grpc.ServerStreamingServer[SubscribeRatesResponse]
+
+func _RateService_GetRateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).GetRateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_GetRateHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).GetRateHistory(ctx, req.(*RateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RateService_GetRateCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateCandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).GetRateCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_GetRateCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).GetRateCandles(ctx, req.(*RateCandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RateService_ServiceDesc is the grpc.ServiceDesc for RateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "exchange.v1.RateService",
+	HandlerType: (*RateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRate",
+			Handler:    _RateService_GetRate_Handler,
+		},
+		{
+			MethodName: "FindConversionPath",
+			Handler:    _RateService_FindConversionPath_Handler,
+		},
+		{
+			MethodName: "ConvertAmount",
+			Handler:    _RateService_ConvertAmount_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _RateService_ListCurrencies_Handler,
+		},
+		{
+			MethodName: "ListRates",
+			Handler:    _RateService_ListRates_Handler,
+		},
+		{
+			MethodName: "GetRateHistory",
+			Handler:    _RateService_GetRateHistory_Handler,
+		},
+		{
+			MethodName: "GetRateCandles",
+			Handler:    _RateService_GetRateCandles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeRates",
+			Handler:       _RateService_SubscribeRates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "exchange/v1/exchange.proto",
+}
+
+const (
+	AdminService_UpsertRate_FullMethodName         = "/exchange.v1.AdminService/UpsertRate"
+	AdminService_UpsertRates_FullMethodName        = "/exchange.v1.AdminService/UpsertRates"
+	AdminService_DeleteRate_FullMethodName         = "/exchange.v1.AdminService/DeleteRate"
+	AdminService_SetRateOverride_FullMethodName    = "/exchange.v1.AdminService/SetRateOverride"
+	AdminService_DeleteRateOverride_FullMethodName = "/exchange.v1.AdminService/DeleteRateOverride"
+	AdminService_ListRateOverrides_FullMethodName  = "/exchange.v1.AdminService/ListRateOverrides"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// API администрирования курсов валют
+type AdminServiceClient interface {
+	// Создание или обновление курса валютной пары
+	UpsertRate(ctx context.Context, in *UpsertRateRequest, opts ...grpc.CallOption) (*ExchangeRate, error)
+	// Создание или обновление нескольких курсов в одной транзакции
+	UpsertRates(ctx context.Context, in *UpsertRatesRequest, opts ...grpc.CallOption) (*UpsertRatesResponse, error)
+	// Удаление курса валютной пары
+	DeleteRate(ctx context.Context, in *DeleteRateRequest, opts ...grpc.CallOption) (*DeleteRateResponse, error)
+	// Ручная фиксация курса валютной пары до указанного момента.
+	// Фиксация действует только на текущий курс и не сохраняется в истории курсов
+	SetRateOverride(ctx context.Context, in *SetRateOverrideRequest, opts ...grpc.CallOption) (*RateOverride, error)
+	// Снятие ручной фиксации курса валютной пары
+	DeleteRateOverride(ctx context.Context, in *DeleteRateOverrideRequest, opts ...grpc.CallOption) (*DeleteRateOverrideResponse, error)
+	// Список действующих ручных фиксаций курсов
+	ListRateOverrides(ctx context.Context, in *ListRateOverridesRequest, opts ...grpc.CallOption) (*ListRateOverridesResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) UpsertRate(ctx context.Context, in *UpsertRateRequest, opts ...grpc.CallOption) (*ExchangeRate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExchangeRate)
+	err := c.cc.Invoke(ctx, AdminService_UpsertRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpsertRates(ctx context.Context, in *UpsertRatesRequest, opts ...grpc.CallOption) (*UpsertRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertRatesResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpsertRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteRate(ctx context.Context, in *DeleteRateRequest, opts ...grpc.CallOption) (*DeleteRateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRateResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetRateOverride(ctx context.Context, in *SetRateOverrideRequest, opts ...grpc.CallOption) (*RateOverride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateOverride)
+	err := c.cc.Invoke(ctx, AdminService_SetRateOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteRateOverride(ctx context.Context, in *DeleteRateOverrideRequest, opts ...grpc.CallOption) (*DeleteRateOverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRateOverrideResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteRateOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListRateOverrides(ctx context.Context, in *ListRateOverridesRequest, opts ...grpc.CallOption) (*ListRateOverridesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRateOverridesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListRateOverrides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// API администрирования курсов валют
+type AdminServiceServer interface {
+	// Создание или обновление курса валютной пары
+	UpsertRate(context.Context, *UpsertRateRequest) (*ExchangeRate, error)
+	// Создание или обновление нескольких курсов в одной транзакции
+	UpsertRates(context.Context, *UpsertRatesRequest) (*UpsertRatesResponse, error)
+	// Удаление курса валютной пары
+	DeleteRate(context.Context, *DeleteRateRequest) (*DeleteRateResponse, error)
+	// Ручная фиксация курса валютной пары до указанного момента.
+	// Фиксация действует только на текущий курс и не сохраняется в истории курсов
+	SetRateOverride(context.Context, *SetRateOverrideRequest) (*RateOverride, error)
+	// Снятие ручной фиксации курса валютной пары
+	DeleteRateOverride(context.Context, *DeleteRateOverrideRequest) (*DeleteRateOverrideResponse, error)
+	// Список действующих ручных фиксаций курсов
+	ListRateOverrides(context.Context, *ListRateOverridesRequest) (*ListRateOverridesResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) UpsertRate(context.Context, *UpsertRateRequest) (*ExchangeRate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertRate not implemented")
+}
+func (UnimplementedAdminServiceServer) UpsertRates(context.Context, *UpsertRatesRequest) (*UpsertRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertRates not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteRate(context.Context, *DeleteRateRequest) (*DeleteRateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRate not implemented")
+}
+func (UnimplementedAdminServiceServer) SetRateOverride(context.Context, *SetRateOverrideRequest) (*RateOverride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRateOverride not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteRateOverride(context.Context, *DeleteRateOverrideRequest) (*DeleteRateOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRateOverride not implemented")
+}
+func (UnimplementedAdminServiceServer) ListRateOverrides(context.Context, *ListRateOverridesRequest) (*ListRateOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRateOverrides not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_UpsertRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpsertRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpsertRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpsertRate(ctx, req.(*UpsertRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpsertRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpsertRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpsertRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpsertRates(ctx, req.(*UpsertRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteRate(ctx, req.(*DeleteRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetRateOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRateOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetRateOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetRateOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetRateOverride(ctx, req.(*SetRateOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteRateOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRateOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteRateOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteRateOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteRateOverride(ctx, req.(*DeleteRateOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListRateOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRateOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListRateOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListRateOverrides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListRateOverrides(ctx, req.(*ListRateOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "exchange.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertRate",
+			Handler:    _AdminService_UpsertRate_Handler,
+		},
+		{
+			MethodName: "UpsertRates",
+			Handler:    _AdminService_UpsertRates_Handler,
+		},
+		{
+			MethodName: "DeleteRate",
+			Handler:    _AdminService_DeleteRate_Handler,
+		},
+		{
+			MethodName: "SetRateOverride",
+			Handler:    _AdminService_SetRateOverride_Handler,
+		},
+		{
+			MethodName: "DeleteRateOverride",
+			Handler:    _AdminService_DeleteRateOverride_Handler,
+		},
+		{
+			MethodName: "ListRateOverrides",
+			Handler:    _AdminService_ListRateOverrides_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "exchange/v1/exchange.proto",
+}
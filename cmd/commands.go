@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// command is a subcommand of the binary.
+type command struct {
+	name    string
+	args    string // arguments after the command name, shown in usage
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+// commands are the subcommands of the binary in the order they are listed in usage.
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "serve", args: "[-c config.env]", summary: "Start the gRPC server", run: runServe},
+		{name: "migrate", args: "[-c config.env] [-dir DIR] up|down|status", summary: "Apply, roll back or list database migrations", run: runMigrate},
+		{name: "import", args: "[-c config.env] FILE", summary: "Load rates from a CSV file into PostgreSQL", run: runImport},
+		{name: "export", args: "[-c config.env] [-as-of RFC3339] [-o FILE]", summary: "Write current or historical rates to a CSV file", run: runExport},
+		{name: "check-config", args: "[-c config.env]", summary: "Validate the configuration and print it", run: runCheckConfig},
+		{name: "version", summary: "Print build info", run: runVersion},
+	}
+}
+
+// findCommand returns the subcommand with the given name.
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// printUsage writes the list of subcommands.
+func printUsage(w io.Writer) {
+	bin := filepath.Base(os.Args[0])
+	fmt.Fprintf(w, "Usage: %s <command> [arguments]\n\nCommands:\n", bin)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-13s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nRun \"%s <command> -h\" for the arguments of a command.\n", bin)
+}
+
+// commandFlags is the flag set of a subcommand with the -c flag shared by all of them.
+type commandFlags struct {
+	*flag.FlagSet
+	configPath *string
+}
+
+// newCommandFlags creates the flag set of the named subcommand.
+func newCommandFlags(name string) *commandFlags {
+	cmd, _ := findCommand(name)
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n\n%s.\n\n", filepath.Base(os.Args[0]), cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+	return &commandFlags{
+		FlagSet:    fs,
+		configPath: fs.String("c", "config.env", "Path to configuration file"),
+	}
+}
+
+// parse parses the arguments of the subcommand and loads the configuration file.
+func (f *commandFlags) parse(args []string) (config, error) {
+	f.Parse(args)
+
+	cfg, err := parseConfig(*f.configPath)
+	if err != nil {
+		return config{}, fmt.Errorf("failed to parse config %s: %w", *f.configPath, err)
+	}
+	return cfg, nil
+}
+
+// runServe starts the gRPC server and blocks until it stops.
+func runServe(ctx context.Context, args []string) error {
+	fs := newCommandFlags("serve")
+	cfg, err := fs.parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	printBuildInfo()
+	if err := run(ctx, cfg); err != nil {
+		return fmt.Errorf("server stopped with error: %w", err)
+	}
+	return nil
+}
+
+// runVersion prints build info to stdout.
+func runVersion(ctx context.Context, args []string) error {
+	fmt.Printf("Version: %s\nCommit: %s\nBuild date: %s\n", buildVersion, buildCommit, buildDate)
+	return nil
+}
+
+// runCheckConfig loads the configuration, validates it and prints the effective values
+// without secrets.
+func runCheckConfig(ctx context.Context, args []string) error {
+	fs := newCommandFlags("check-config")
+	cfg, err := fs.parse(args)
+	if err != nil {
+		return err
+	}
+	if _, err := zapcore.ParseLevel(cfg.logLevel); err != nil {
+		return fmt.Errorf("APP_LOG_LEVEL: %w", err)
+	}
+
+	fmt.Printf("Configuration %s is valid\n", *fs.configPath)
+	fmt.Printf("  listen address: %s:%s\n", cfg.appHost, cfg.appPort)
+	fmt.Printf("  log level:      %s\n", cfg.logLevel)
+	fmt.Printf("  postgres:       %s@%s:%d/%s\n", cfg.pgUser, cfg.pgHost, cfg.pgPort, cfg.pgDB)
+	fmt.Printf("  auto migrate:   %t\n", cfg.migrationsAutoApply)
+	fmt.Printf("  rates store:    %s\n", cfg.ratesStore)
+	if cfg.ratesStore == ratesStoreRedis {
+		fmt.Printf("  redis:          %s/%d, sync every %s\n", cfg.redisAddr, cfg.redisDB, cfg.redisSyncInterval)
+	}
+	fmt.Printf("  rates cache:    %t\n", cfg.ratesCacheEnabled)
+	fmt.Printf("  ecb provider:   %t\n", cfg.ecbProviderEnabled)
+	fmt.Printf("  cbr provider:   %t\n", cfg.cbrProviderEnabled)
+	fmt.Printf("  health probe:   every %s, rates max age %s\n", cfg.healthProbeInterval, cfg.healthRatesMaxAge)
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/logger"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/sbilibin2017/gw-exchanger/internal/ratecsv"
+	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
+	"github.com/sbilibin2017/gw-exchanger/internal/services"
+)
+
+// runImport loads rates from a CSV file (from,to,rate,effective_at) into PostgreSQL
+// in a single transaction. Nothing is loaded if any record is invalid.
+// FILE "-" reads stdin.
+func runImport(ctx context.Context, args []string) error {
+	fs := newCommandFlags("import")
+	cfg, err := fs.parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected a single FILE argument")
+	}
+	path := fs.Arg(0)
+
+	log, err := logger.New(cfg.logLevel)
+	if err != nil {
+		return err
+	}
+	defer log.Sync()
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	db, err := connectPostgres(ctx, log, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	currencies := services.NewCurrencyRegistry(log, repositories.NewCurrencyRepository(log, db), 0)
+	if err := currencies.Reload(ctx); err != nil {
+		return err
+	}
+
+	rates, err := ratecsv.Read(in, currencies.Supported, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	current, err := repositories.NewExchangeRateWriteRepository(log, db).Import(ctx, rates)
+	if err != nil {
+		return err
+	}
+	log.Infof("Imported %d rates from %s, current rates updated: %d", len(rates), path, current)
+
+	return nil
+}
+
+// runExport writes the current rate book, or the one effective at -as-of,
+// from PostgreSQL to a CSV file in the format accepted by import.
+func runExport(ctx context.Context, args []string) error {
+	fs := newCommandFlags("export")
+	asOfFlag := fs.String("as-of", "", "Export rates effective at the RFC 3339 instant instead of the current ones")
+	outPath := fs.String("o", "-", "Output file, \"-\" writes to stdout")
+	cfg, err := fs.parse(args)
+	if err != nil {
+		return err
+	}
+
+	var asOf *time.Time
+	if *asOfFlag != "" {
+		t, err := time.Parse(time.RFC3339Nano, *asOfFlag)
+		if err != nil {
+			return fmt.Errorf("invalid -as-of: %w", err)
+		}
+		asOf = &t
+	}
+
+	log, err := logger.New(cfg.logLevel)
+	if err != nil {
+		return err
+	}
+	defer log.Sync()
+
+	db, err := connectPostgres(ctx, log, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	reader := repositories.NewExchangeRateReadRepository(log, db)
+	var rates []models.ExchangeRateHistoryDB
+	if asOf != nil {
+		if rates, err = reader.ListAt(ctx, asOf.UTC()); err != nil {
+			return err
+		}
+	} else {
+		rows, err := reader.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			rates = append(rates, models.ExchangeRateHistoryDB{
+				FromCurrency: r.FromCurrency,
+				ToCurrency:   r.ToCurrency,
+				Rate:         r.Rate,
+				EffectiveAt:  r.UpdatedAt,
+			})
+		}
+		sort.Slice(rates, func(i, j int) bool {
+			if rates[i].FromCurrency != rates[j].FromCurrency {
+				return rates[i].FromCurrency < rates[j].FromCurrency
+			}
+			return rates[i].ToCurrency < rates[j].ToCurrency
+		})
+	}
+
+	var out io.Writer = os.Stdout
+	if *outPath != "-" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := ratecsv.Write(out, rates); err != nil {
+		return err
+	}
+	log.Infof("Exported %d rates to %s", len(rates), *outPath)
+
+	return nil
+}
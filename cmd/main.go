@@ -2,23 +2,33 @@ package main
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
 	"github.com/sbilibin2017/gw-exchanger/internal/logger"
 	"github.com/sbilibin2017/gw-exchanger/internal/middlewares"
+	"github.com/sbilibin2017/gw-exchanger/internal/providers"
 	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
 	"github.com/sbilibin2017/gw-exchanger/internal/services"
+	"github.com/sbilibin2017/gw-exchanger/migrations"
 	pb "github.com/sbilibin2017/proto-exchange/exchange"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
@@ -31,51 +41,105 @@ var (
 )
 
 // main is the entry point of the application.
-// It prints build info, parses configuration, and starts the gRPC server.
+// It runs the subcommand given as the first argument; without one the gRPC server is started.
 func main() {
-	printBuildInfo()
-	configPath := parseFlags()
+	args := os.Args[1:]
+	// Plain "-c config.env" invocations predate subcommands and start the server.
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && args[0] != "-h" && args[0] != "--help") {
+		args = append([]string{"serve"}, args...)
+	}
 
-	appHost, appPort,
-		pgHost, pgPort, pgUser, pgPassword, pgDB,
-		pgMaxOpenConns, pgMaxIdleConns,
-		logLevel, err := parseConfig(configPath)
-	if err != nil {
-		log.Fatalf("failed to parse config: %v", err)
+	name := args[0]
+	cmd, ok := findCommand(name)
+	if !ok {
+		printUsage(os.Stderr)
+		if name == "help" || name == "-h" || name == "--help" {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "\nunknown command: %s\n", name)
+		os.Exit(2)
 	}
 
-	if err := run(context.Background(),
-		appHost, appPort,
-		pgHost, pgPort, pgUser, pgPassword, pgDB,
-		pgMaxOpenConns, pgMaxIdleConns,
-		logLevel,
-	); err != nil {
-		log.Fatalf("server stopped with error: %v", err)
+	if err := cmd.run(context.Background(), args[1:]); err != nil {
+		log.Fatalf("%s: %v", name, err)
 	}
 }
 
-// printBuildInfo prints build info to stdout, each field on a new line.
+// printBuildInfo prints build info to the log, each field on a new line.
 func printBuildInfo() {
 	log.Printf("Version: %s", buildVersion)
 	log.Printf("Commit: %s", buildCommit)
 	log.Printf("Build date: %s", buildDate)
 }
 
-// parseFlags parses the -c flag for configuration file path.
-func parseFlags() string {
-	c := flag.String("c", "config.env", "Path to configuration file")
-	flag.Parse()
-	return *c
+// Rate stores supported by the RATES_STORE setting.
+const (
+	ratesStorePostgres = "postgres"
+	ratesStoreRedis    = "redis"
+)
+
+// config holds the service configuration.
+type config struct {
+	appHost  string
+	appPort  string
+	logLevel string
+
+	pgHost         string
+	pgPort         int
+	pgUser         string
+	pgPassword     string
+	pgDB           string
+	pgMaxOpenConns int
+	pgMaxIdleConns int
+
+	migrationsAutoApply bool // apply pending migrations on server start
+
+	ratesStore        string // store used to read rates: postgres or redis
+	redisAddr         string
+	redisPassword     string
+	redisDB           int
+	redisSyncInterval time.Duration // period of the full copy of the rate book to Redis
+
+	ratesCacheEnabled        bool          // keep the rate book in memory
+	ratesCacheReloadInterval time.Duration // period of the full cache reload
+
+	ratesStreamReloadInterval time.Duration // period of the rate book reload for SubscribeRates
+
+	currenciesReloadInterval time.Duration // period of the currency table reload
+
+	rateOverridesReloadInterval time.Duration // period of the manual rate overrides reload
+
+	providersTimeout time.Duration // upper bound of a single provider run
+	providersJitter  time.Duration // upper bound of the random delay of provider runs
+
+	providersAggregation      providers.AggregationMethod // how quotes of several providers are combined
+	providersWeights          map[string]decimal.Decimal  // provider weights for weighted_mean
+	providersPriority         []string                    // provider order for priority
+	providersOutlierDeviation decimal.Decimal             // relative deviation from the median rejecting a quote, zero disables
+	providersQuoteMaxAge      time.Duration               // age after which provider quotes are ignored
+
+	ecbProviderEnabled  bool
+	ecbProviderURL      string // base URL of the ECB website
+	ecbProviderInterval time.Duration
+
+	cbrProviderEnabled  bool
+	cbrProviderURL      string // base URL of the CBR website
+	cbrProviderInterval time.Duration
+
+	ratesPivotCurrency    string                 // currency used to derive missing pairs, empty disables
+	ratesInversePolicy    services.InversePolicy // how rates are derived from the opposite direction
+	ratesInverseTolerance decimal.Decimal        // allowed deviation of a pair's round trip from 1
+
+	healthProbeInterval time.Duration // period of the health checks
+	healthProbeTimeout  time.Duration // upper bound of a single health check
+	healthRatesMaxAge   time.Duration // age of the latest rate update reported as stale, zero disables
+
+	shutdownDrainDelay time.Duration // time between reporting NOT_SERVING and stopping the server
+	shutdownTimeout    time.Duration // upper bound of the graceful stop before open streams are cut
 }
 
 // parseConfig loads environment variables and returns configuration values.
-func parseConfig(path string) (
-	appHost, appPort string,
-	pgHost string, pgPort int, pgUser, pgPassword, pgDB string,
-	pgMaxOpenConns, pgMaxIdleConns int,
-	logLevel string,
-	err error,
-) {
+func parseConfig(path string) (cfg config, err error) {
 	godotenv.Load(path)
 
 	getEnv := func(key, def string) string {
@@ -85,65 +149,368 @@ func parseConfig(path string) (
 		return def
 	}
 
-	appHost = getEnv("APP_HOST", "localhost")
-	appPort = getEnv("APP_PORT", "50051")
-	logLevel = getEnv("APP_LOG_LEVEL", "info")
+	cfg.appHost = getEnv("APP_HOST", "localhost")
+	cfg.appPort = getEnv("APP_PORT", "50051")
+	cfg.logLevel = getEnv("APP_LOG_LEVEL", "info")
+
+	cfg.pgHost = getEnv("POSTGRES_HOST", "localhost")
+	cfg.pgUser = getEnv("POSTGRES_USER", "exchange_rate_user")
+	cfg.pgPassword = getEnv("POSTGRES_PASSWORD", "exchange_rate_password")
+	cfg.pgDB = getEnv("POSTGRES_DB", "exchange_rate_db")
+	if cfg.pgPort, err = strconv.Atoi(getEnv("POSTGRES_PORT", "5432")); err != nil {
+		return
+	}
+	if cfg.pgMaxOpenConns, err = strconv.Atoi(getEnv("POSTGRES_MAX_OPEN_CONNS", "16")); err != nil {
+		return
+	}
+	if cfg.pgMaxIdleConns, err = strconv.Atoi(getEnv("POSTGRES_MAX_IDLE_CONNS", "8")); err != nil {
+		return
+	}
+
+	if cfg.migrationsAutoApply, err = strconv.ParseBool(getEnv("MIGRATIONS_AUTO_APPLY", "false")); err != nil {
+		return
+	}
+
+	cfg.ratesStore = getEnv("RATES_STORE", ratesStorePostgres)
+	if cfg.ratesStore != ratesStorePostgres && cfg.ratesStore != ratesStoreRedis {
+		err = fmt.Errorf("unknown RATES_STORE: %s", cfg.ratesStore)
+		return
+	}
+	cfg.redisAddr = getEnv("REDIS_ADDR", "localhost:6379")
+	cfg.redisPassword = getEnv("REDIS_PASSWORD", "")
+	if cfg.redisDB, err = strconv.Atoi(getEnv("REDIS_DB", "0")); err != nil {
+		return
+	}
+	if cfg.redisSyncInterval, err = time.ParseDuration(getEnv("REDIS_SYNC_INTERVAL", "1m")); err != nil {
+		return
+	}
+
+	if cfg.ratesCacheEnabled, err = strconv.ParseBool(getEnv("RATES_CACHE_ENABLED", "false")); err != nil {
+		return
+	}
+	if cfg.ratesCacheReloadInterval, err = time.ParseDuration(getEnv("RATES_CACHE_RELOAD_INTERVAL", "5m")); err != nil {
+		return
+	}
+
+	if cfg.ratesStreamReloadInterval, err = time.ParseDuration(getEnv("RATES_STREAM_RELOAD_INTERVAL", "1m")); err != nil {
+		return
+	}
+
+	if cfg.currenciesReloadInterval, err = time.ParseDuration(getEnv("CURRENCIES_RELOAD_INTERVAL", "1m")); err != nil {
+		return
+	}
+
+	if cfg.rateOverridesReloadInterval, err = time.ParseDuration(getEnv("RATE_OVERRIDES_RELOAD_INTERVAL", "1m")); err != nil {
+		return
+	}
+
+	if cfg.providersTimeout, err = time.ParseDuration(getEnv("PROVIDERS_TIMEOUT", "30s")); err != nil {
+		return
+	}
+	if cfg.providersJitter, err = time.ParseDuration(getEnv("PROVIDERS_JITTER", "10s")); err != nil {
+		return
+	}
+	if cfg.providersAggregation, err = providers.ParseAggregationMethod(getEnv("PROVIDERS_AGGREGATION", "median")); err != nil {
+		return
+	}
+	if cfg.providersWeights, err = providers.ParseWeights(getEnv("PROVIDERS_WEIGHTS", "")); err != nil {
+		return
+	}
+	for _, source := range strings.Split(getEnv("PROVIDERS_PRIORITY", ""), ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			cfg.providersPriority = append(cfg.providersPriority, source)
+		}
+	}
+	if cfg.providersOutlierDeviation, err = decimal.NewFromString(getEnv("PROVIDERS_OUTLIER_DEVIATION", "0.05")); err != nil {
+		return
+	}
+	if cfg.providersQuoteMaxAge, err = time.ParseDuration(getEnv("PROVIDERS_QUOTE_MAX_AGE", "6h")); err != nil {
+		return
+	}
+
+	if cfg.ecbProviderEnabled, err = strconv.ParseBool(getEnv("ECB_PROVIDER_ENABLED", "false")); err != nil {
+		return
+	}
+	cfg.ecbProviderURL = getEnv("ECB_PROVIDER_URL", providers.ECBBaseURL)
+	if cfg.ecbProviderInterval, err = time.ParseDuration(getEnv("ECB_PROVIDER_INTERVAL", "1h")); err != nil {
+		return
+	}
+	if cfg.ecbProviderInterval <= 0 {
+		err = fmt.Errorf("ECB_PROVIDER_INTERVAL must be positive: %s", cfg.ecbProviderInterval)
+		return
+	}
+
+	if cfg.cbrProviderEnabled, err = strconv.ParseBool(getEnv("CBR_PROVIDER_ENABLED", "false")); err != nil {
+		return
+	}
+	cfg.cbrProviderURL = getEnv("CBR_PROVIDER_URL", providers.CBRBaseURL)
+	if cfg.cbrProviderInterval, err = time.ParseDuration(getEnv("CBR_PROVIDER_INTERVAL", "1h")); err != nil {
+		return
+	}
+	if cfg.cbrProviderInterval <= 0 {
+		err = fmt.Errorf("CBR_PROVIDER_INTERVAL must be positive: %s", cfg.cbrProviderInterval)
+		return
+	}
+
+	cfg.ratesPivotCurrency = getEnv("RATES_PIVOT_CURRENCY", "")
+	if cfg.ratesInversePolicy, err = services.ParseInversePolicy(getEnv("RATES_INVERSE_POLICY", "")); err != nil {
+		return
+	}
+	if cfg.ratesInverseTolerance, err = decimal.NewFromString(getEnv("RATES_INVERSE_TOLERANCE", "0.001")); err != nil {
+		return
+	}
 
-	pgHost = getEnv("POSTGRES_HOST", "localhost")
-	pgUser = getEnv("POSTGRES_USER", "exchange_rate_user")
-	pgPassword = getEnv("POSTGRES_PASSWORD", "exchange_rate_password")
-	pgDB = getEnv("POSTGRES_DB", "exchange_rate_db")
-	if pgPort, err = strconv.Atoi(getEnv("POSTGRES_PORT", "5432")); err != nil {
+	if cfg.healthProbeInterval, err = time.ParseDuration(getEnv("HEALTH_PROBE_INTERVAL", "5s")); err != nil {
+		return
+	}
+	if cfg.healthProbeInterval <= 0 {
+		err = fmt.Errorf("HEALTH_PROBE_INTERVAL must be positive: %s", cfg.healthProbeInterval)
+		return
+	}
+	if cfg.healthProbeTimeout, err = time.ParseDuration(getEnv("HEALTH_PROBE_TIMEOUT", "2s")); err != nil {
+		return
+	}
+	if cfg.healthProbeTimeout <= 0 {
+		err = fmt.Errorf("HEALTH_PROBE_TIMEOUT must be positive: %s", cfg.healthProbeTimeout)
+		return
+	}
+	if cfg.healthRatesMaxAge, err = time.ParseDuration(getEnv("HEALTH_RATES_MAX_AGE", "0s")); err != nil {
 		return
 	}
-	if pgMaxOpenConns, err = strconv.Atoi(getEnv("POSTGRES_MAX_OPEN_CONNS", "16")); err != nil {
+
+	if cfg.shutdownDrainDelay, err = time.ParseDuration(getEnv("SHUTDOWN_DRAIN_DELAY", "0s")); err != nil {
 		return
 	}
-	if pgMaxIdleConns, err = strconv.Atoi(getEnv("POSTGRES_MAX_IDLE_CONNS", "8")); err != nil {
+	if cfg.shutdownTimeout, err = time.ParseDuration(getEnv("SHUTDOWN_TIMEOUT", "30s")); err != nil {
 		return
 	}
 
 	return
 }
 
+// postgresDSN returns the connection string of the PostgreSQL database.
+func (cfg config) postgresDSN() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		cfg.pgUser, cfg.pgPassword, cfg.pgHost, cfg.pgPort, cfg.pgDB)
+}
+
+// connectPostgres opens the PostgreSQL connection pool.
+func connectPostgres(ctx context.Context, log *zap.SugaredLogger, cfg config) (*sqlx.DB, error) {
+	log.Infof("Connecting to PostgreSQL: %s:%d", cfg.pgHost, cfg.pgPort)
+	db, err := sqlx.ConnectContext(ctx, "pgx", cfg.postgresDSN())
+	if err != nil {
+		log.Errorf("DB connection error: %v", err)
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.pgMaxOpenConns)
+	db.SetMaxIdleConns(cfg.pgMaxIdleConns)
+	log.Infof("PostgreSQL connected, MaxOpenConns=%d, MaxIdleConns=%d", cfg.pgMaxOpenConns, cfg.pgMaxIdleConns)
+	return db, nil
+}
+
 // run initializes logger, database, service, and starts the gRPC server with graceful shutdown.
-func run(ctx context.Context,
-	appHost, appPort string,
-	pgHost string, pgPort int, pgUser, pgPassword, pgDB string,
-	pgMaxOpenConns, pgMaxIdleConns int,
-	logLevel string,
-) error {
-	log, err := logger.New(logLevel)
+func run(ctx context.Context, cfg config) error {
+	log, err := logger.New(cfg.logLevel)
 	if err != nil {
 		fmt.Printf("failed to init logger: %v\n", err)
 		return err
 	}
 	defer log.Sync()
-	log.Infof("Logger initialized, level: %s", logLevel)
+	log.Infof("Logger initialized, level: %s", cfg.logLevel)
 
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		pgUser, pgPassword, pgHost, pgPort, pgDB)
-	log.Infof("Connecting to PostgreSQL: %s:%d", pgHost, pgPort)
-	db, err := sqlx.ConnectContext(ctx, "pgx", dsn)
+	dsn := cfg.postgresDSN()
+	db, err := connectPostgres(ctx, log, cfg)
 	if err != nil {
-		log.Errorf("DB connection error: %v", err)
 		return err
 	}
 	defer db.Close()
-	db.SetMaxOpenConns(pgMaxOpenConns)
-	db.SetMaxIdleConns(pgMaxIdleConns)
-	log.Infof("PostgreSQL connected, MaxOpenConns=%d, MaxIdleConns=%d", pgMaxOpenConns, pgMaxIdleConns)
 
-	readRepo := repositories.NewExchangeRateReadRepository(log, db)
+	if cfg.migrationsAutoApply {
+		log.Info("Applying database migrations")
+		provider, err := migrations.NewProvider(db.DB, nil)
+		if err != nil {
+			log.Errorf("Migrations error: %v", err)
+			return err
+		}
+		if err := migrations.Up(ctx, log, provider); err != nil {
+			return err
+		}
+	}
 
-	exchangeService := services.NewExchangeRateService(log, readRepo)
+	// Rate history is kept only in PostgreSQL regardless of RATES_STORE.
+	pgReader := repositories.NewExchangeRateReadRepository(log, db)
+
+	var (
+		reader    services.ExchangeRateReader
+		redisRepo *repositories.ExchangeRateRedisRepository
+	)
+	switch cfg.ratesStore {
+	case ratesStoreRedis:
+		log.Infof("Connecting to Redis: %s", cfg.redisAddr)
+		rdb := redis.NewClient(&redis.Options{
+			Addr:     cfg.redisAddr,
+			Password: cfg.redisPassword,
+			DB:       cfg.redisDB,
+		})
+		defer rdb.Close()
+		if err := rdb.Ping(ctx).Err(); err != nil {
+			log.Errorf("Redis connection error: %v", err)
+			return err
+		}
+		log.Info("Redis connected, reading current rates from Redis")
+		redisRepo = repositories.NewExchangeRateRedisRepository(log, rdb)
+		reader = repositories.NewExchangeRateRedisReader(redisRepo, pgReader)
+	default:
+		reader = pgReader
+	}
+
+	// Overrides are always read from PostgreSQL; the listener reloads them together with the rates.
+	// The hub is notified after every reload and when an override expires.
+	var hub *services.RateHub
+	overrideRepo := repositories.NewRateOverrideRepository(log, db)
+	overrides := services.NewRateOverrides(log, overrideRepo, cfg.rateOverridesReloadInterval, func() { hub.Notify() })
+	if err := overrides.Reload(ctx); err != nil {
+		log.Errorf("Rate overrides load error: %v", err)
+		return err
+	}
+	log.Infof("Rate overrides loaded, reload interval: %s", cfg.rateOverridesReloadInterval)
+
+	// The hub diffs the rate book read from the store itself, not from the cache,
+	// so that it never observes a snapshot older than the notification it reacts to.
+	// Overrides are applied to it like to the rates served by the service.
+	hub = services.NewRateHub(log, services.NewOverriddenExchangeRateReader(reader, overrides), cfg.ratesStreamReloadInterval)
+	hubCtx, cancelHub := context.WithCancel(ctx)
+	defer cancelHub()
+	go hub.Run(hubCtx)
+	go overrides.Run(hubCtx)
+
+	onRatesChanged := func() {
+		overrides.Notify()
+		hub.Notify()
+	}
+
+	if cfg.ratesCacheEnabled {
+		cacheCtx, cancelCache := context.WithCancel(ctx)
+		defer cancelCache()
+
+		cache := repositories.NewExchangeRateCache(log, reader, cfg.ratesCacheReloadInterval)
+		go cache.Run(cacheCtx)
+		onRatesChanged = func() {
+			cache.Invalidate()
+			overrides.Notify()
+			hub.Notify()
+		}
+		log.Infof("Rates cache enabled, reload interval: %s", cfg.ratesCacheReloadInterval)
+		reader = cache
+	}
+
+	listener := repositories.NewExchangeRateListener(log, dsn)
+	if redisRepo != nil {
+		// Rates are written to PostgreSQL only: Redis gets a copy of the rate book
+		// on every change, and readers are notified once the copy is written.
+		redisSync := repositories.NewExchangeRateRedisSync(log, pgReader, redisRepo, cfg.redisSyncInterval, onRatesChanged)
+		go redisSync.Run(hubCtx)
+		go listener.Listen(hubCtx, redisSync.Notify)
+		log.Infof("Rates copied to Redis on change, sync interval: %s", cfg.redisSyncInterval)
+	} else {
+		go listener.Listen(hubCtx, onRatesChanged)
+	}
+	log.Infof("Rates streaming enabled, reload interval: %s", cfg.ratesStreamReloadInterval)
+
+	writeRepo := repositories.NewExchangeRateWriteRepository(log, db)
+
+	currencies := services.NewCurrencyRegistry(log, repositories.NewCurrencyRepository(log, db), cfg.currenciesReloadInterval)
+	if err := currencies.Reload(ctx); err != nil {
+		log.Errorf("Currencies load error: %v", err)
+		return err
+	}
+	currenciesCtx, cancelCurrencies := context.WithCancel(ctx)
+	defer cancelCurrencies()
+	go currencies.Run(currenciesCtx)
+	log.Infof("Currencies loaded, reload interval: %s", cfg.currenciesReloadInterval)
+
+	// Providers always write to PostgreSQL and compare against its rate book.
+	aggregator := providers.NewAggregator(
+		cfg.providersAggregation,
+		providers.WithWeights(cfg.providersWeights),
+		providers.WithPriority(cfg.providersPriority),
+		providers.WithOutlierRejection(cfg.providersOutlierDeviation),
+		providers.WithMaxQuoteAge(cfg.providersQuoteMaxAge),
+	)
+	pipeline := providers.NewPipeline(log, pgReader, repositories.NewRateQuoteRepository(log, db), writeRepo, currencies, aggregator)
+	scheduler := providers.NewScheduler(log, pipeline, cfg.providersTimeout, cfg.providersJitter)
+	httpClient := &http.Client{}
+	if cfg.ecbProviderEnabled {
+		log.Infof("ECB provider enabled: %s, interval: %s", cfg.ecbProviderURL, cfg.ecbProviderInterval)
+		if err := scheduler.Add(providers.NewECBProvider(httpClient, cfg.ecbProviderURL), cfg.ecbProviderInterval); err != nil {
+			return err
+		}
+	}
+	if cfg.cbrProviderEnabled {
+		log.Infof("CBR provider enabled: %s, interval: %s", cfg.cbrProviderURL, cfg.cbrProviderInterval)
+		if err := scheduler.Add(providers.NewCBRProvider(httpClient, cfg.cbrProviderURL), cfg.cbrProviderInterval); err != nil {
+			return err
+		}
+	}
+	if scheduler.Len() > 0 {
+		schedulerCtx, cancelScheduler := context.WithCancel(ctx)
+		defer cancelScheduler()
+		go scheduler.Run(schedulerCtx)
+		log.Infof("Rate providers started: %d, timeout: %s, jitter: %s, aggregation: %s",
+			scheduler.Len(), cfg.providersTimeout, cfg.providersJitter, cfg.providersAggregation)
+	}
+
+	serviceOpts := []services.ExchangeRateServiceOption{
+		services.WithRateHub(hub),
+		services.WithHistoryReader(pgReader),
+		services.WithRateOverrides(overrides),
+	}
+	if cfg.ratesPivotCurrency != "" {
+		log.Infof("Cross rates enabled, pivot currency: %s", cfg.ratesPivotCurrency)
+		serviceOpts = append(serviceOpts, services.WithPivotCurrency(cfg.ratesPivotCurrency))
+	}
+	if cfg.ratesInversePolicy != services.InversePolicyNone {
+		log.Infof("Inverse rates enabled, policy: %s, tolerance: %s", cfg.ratesInversePolicy, cfg.ratesInverseTolerance)
+		serviceOpts = append(serviceOpts, services.WithInversePolicy(cfg.ratesInversePolicy, cfg.ratesInverseTolerance))
+	}
+
+	exchangeService := services.NewExchangeRateService(log, reader, currencies, serviceOpts...)
+	adminService := services.NewExchangeRateAdminService(log, writeRepo, currencies,
+		services.WithRateOverrideWriter(overrideRepo, overrides),
+	)
 
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(middlewares.LoggingMiddleware(log)),
+		grpc.StreamInterceptor(middlewares.StreamLoggingMiddleware(log)),
 	)
 	pb.RegisterExchangeServiceServer(grpcServer, exchangeService)
+	exchangev1.RegisterRateServiceServer(grpcServer, exchangeService)
+	exchangev1.RegisterAdminServiceServer(grpcServer, adminService)
+
+	// The health service reports NOT_SERVING until the first probe passes.
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	probe, err := services.NewHealthProbe(log, healthServer,
+		[]string{
+			pb.ExchangeService_ServiceDesc.ServiceName,
+			exchangev1.RateService_ServiceDesc.ServiceName,
+			exchangev1.AdminService_ServiceDesc.ServiceName,
+		},
+		db, cfg.healthProbeInterval, cfg.healthProbeTimeout,
+		services.WithRateFreshness(pgReader, cfg.healthRatesMaxAge),
+	)
+	if err != nil {
+		log.Errorf("Health probe error: %v", err)
+		return err
+	}
+	probeCtx, cancelProbe := context.WithCancel(ctx)
+	defer cancelProbe()
+	go probe.Run(probeCtx)
+	log.Infof("Health probe started, interval: %s, timeout: %s, rates max age: %s",
+		cfg.healthProbeInterval, cfg.healthProbeTimeout, cfg.healthRatesMaxAge)
 
-	listenAddr := fmt.Sprintf("%s:%s", appHost, appPort)
+	listenAddr := fmt.Sprintf("%s:%s", cfg.appHost, cfg.appPort)
 	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		log.Errorf("Listener error: %v", err)
@@ -165,8 +532,16 @@ func run(ctx context.Context,
 	select {
 	case <-shutdownCtx.Done():
 		log.Info("Shutdown signal received, stopping gRPC server...")
-		grpcServer.GracefulStop()
-		log.Info("gRPC server stopped gracefully")
+		// Report NOT_SERVING before stopping so that load balancers stop routing new calls here.
+		cancelProbe()
+		healthServer.Shutdown()
+		if cfg.shutdownDrainDelay > 0 {
+			log.Infof("Draining for %s", cfg.shutdownDrainDelay)
+			time.Sleep(cfg.shutdownDrainDelay)
+		}
+		// Close rate subscriptions first: GracefulStop waits for open streams.
+		cancelHub()
+		stopServer(log, grpcServer, cfg.shutdownTimeout)
 	case serveErr := <-errChan:
 		log.Errorf("gRPC server exited with error: %v", serveErr)
 		return serveErr
@@ -174,3 +549,22 @@ func run(ctx context.Context,
 
 	return nil
 }
+
+// stopServer stops the gRPC server gracefully and cuts the remaining calls,
+// such as health Watch streams, once timeout elapses.
+func stopServer(log *zap.SugaredLogger, grpcServer *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		log.Info("gRPC server stopped gracefully")
+	case <-time.After(timeout):
+		log.Warnf("gRPC server did not stop within %s, closing remaining connections", timeout)
+		grpcServer.Stop()
+		<-stopped
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigRejectsNonPositiveHealthProbePeriods(t *testing.T) {
+	testCases := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "zero interval", key: "HEALTH_PROBE_INTERVAL", value: "0s"},
+		{name: "negative interval", key: "HEALTH_PROBE_INTERVAL", value: "-5s"},
+		{name: "zero timeout", key: "HEALTH_PROBE_TIMEOUT", value: "0s"},
+		{name: "negative timeout", key: "HEALTH_PROBE_TIMEOUT", value: "-2s"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(tc.key, tc.value)
+
+			_, err := parseConfig("")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.key+" must be positive")
+		})
+	}
+}
+
+func TestParseConfigHealthProbeDefaults(t *testing.T) {
+	t.Setenv("HEALTH_PROBE_INTERVAL", "")
+	t.Setenv("HEALTH_PROBE_TIMEOUT", "")
+
+	cfg, err := parseConfig("")
+
+	require.NoError(t, err)
+	assert.Positive(t, cfg.healthProbeInterval)
+	assert.Positive(t, cfg.healthProbeTimeout)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	iofs "io/fs"
+	"os"
+
+	"github.com/pressly/goose/v3"
+	"github.com/sbilibin2017/gw-exchanger/internal/logger"
+	"github.com/sbilibin2017/gw-exchanger/migrations"
+)
+
+// runMigrate applies, rolls back or lists the embedded migrations, or those in -dir, against PostgreSQL:
+// "up" applies all pending migrations, "down" rolls back the last applied one,
+// "status" prints the state of every migration.
+func runMigrate(ctx context.Context, args []string) error {
+	fs := newCommandFlags("migrate")
+	dir := fs.String("dir", "", "Directory with SQL migrations instead of the embedded ones")
+	cfg, err := fs.parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one of up, down, status")
+	}
+	action := fs.Arg(0)
+	if action != "up" && action != "down" && action != "status" {
+		fs.Usage()
+		return fmt.Errorf("unknown migrate action %q, expected one of up, down, status", action)
+	}
+
+	log, err := logger.New(cfg.logLevel)
+	if err != nil {
+		return err
+	}
+	defer log.Sync()
+
+	db, err := connectPostgres(ctx, log, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var source iofs.FS
+	if *dir != "" {
+		source = os.DirFS(*dir)
+	}
+	provider, err := migrations.NewProvider(db.DB, source)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		if err := migrations.Up(ctx, log, provider); err != nil {
+			return err
+		}
+	case "down":
+		r, err := provider.Down(ctx)
+		if err != nil {
+			return err
+		}
+		log.Infof("Migration rolled back: %s (%s)", r.Source.Path, r.Duration)
+	case "status":
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			appliedAt := "-"
+			if s.State == goose.StateApplied {
+				appliedAt = s.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-8s %-19s %s\n", s.State, appliedAt, s.Source.Path)
+		}
+	}
+
+	return nil
+}
@@ -53,3 +53,90 @@ func LoggingMiddleware(log *zap.SugaredLogger) grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// StreamLoggingMiddleware returns a gRPC stream interceptor that logs the start of a stream,
+// every request message and the end of the stream with the number of sent messages.
+func StreamLoggingMiddleware(log *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		reqID := uuid.New().String()
+		start := time.Now()
+
+		log.Infow("stream",
+			"request_id", reqID,
+			"method", info.FullMethod,
+		)
+
+		// Call the handler with the request ID in the stream context
+		stream := &loggingServerStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), "request_id", reqID),
+			reqID:        reqID,
+			method:       info.FullMethod,
+			log:          log,
+		}
+		err := handler(srv, stream)
+
+		duration := time.Since(start)
+
+		if err != nil {
+			log.Errorw("stream error",
+				"request_id", reqID,
+				"method", info.FullMethod,
+				"error", err,
+				"sent", stream.sent,
+				"duration", duration,
+			)
+		} else {
+			log.Infow("stream closed",
+				"request_id", reqID,
+				"method", info.FullMethod,
+				"sent", stream.sent,
+				"duration", duration,
+			)
+		}
+
+		return err
+	}
+}
+
+// loggingServerStream is a server stream that logs received messages and counts sent ones.
+type loggingServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	reqID  string
+	method string
+	sent   int
+	log    *zap.SugaredLogger
+}
+
+// Context returns the stream context with the request ID.
+func (s *loggingServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg receives a request message and logs it.
+func (s *loggingServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.log.Infow("request",
+			"request_id", s.reqID,
+			"method", s.method,
+			"request", m,
+		)
+	}
+	return err
+}
+
+// SendMsg sends a response message and counts it.
+func (s *loggingServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+	}
+	return err
+}
@@ -73,3 +73,71 @@ func TestLoggingMiddleware(t *testing.T) {
 		})
 	}
 }
+
+// fakeServerStream is a server stream with a single request message.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+func (s *fakeServerStream) RecvMsg(m any) error      { return nil }
+func (s *fakeServerStream) SendMsg(m any) error      { return nil }
+
+func TestStreamLoggingMiddleware(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := newTestLogger(buf)
+
+	testCases := []struct {
+		name      string
+		handler   grpc.StreamHandler
+		expectErr bool
+	}{
+		{
+			name: "stream completed",
+			handler: func(srv any, stream grpc.ServerStream) error {
+				if err := stream.RecvMsg("request"); err != nil {
+					return err
+				}
+				require.NotNil(t, stream.Context().Value("request_id"))
+				for i := 0; i < 2; i++ {
+					if err := stream.SendMsg("update"); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			expectErr: false,
+		},
+		{
+			name: "stream fails",
+			handler: func(srv any, stream grpc.ServerStream) error {
+				return errors.New("stream error")
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			interceptor := StreamLoggingMiddleware(logger)
+
+			err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{
+				FullMethod:     "/test/stream",
+				IsServerStream: true,
+			}, tc.handler)
+
+			logs := buf.String()
+			require.Contains(t, logs, "/test/stream")
+			if tc.expectErr {
+				require.Error(t, err)
+				require.Contains(t, logs, "stream error")
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, logs, `"sent":2`)
+			}
+
+			buf.Reset()
+		})
+	}
+}
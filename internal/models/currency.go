@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// CurrencyDB describes the model of an ISO 4217 currency record
+// stored in the database.
+type CurrencyDB struct {
+	Code        string    `json:"code" db:"code"`                 // Alphabetic ISO 4217 code
+	NumericCode int32     `json:"numeric_code" db:"numeric_code"` // Numeric ISO 4217 code
+	Name        string    `json:"name" db:"name"`                 // Currency name
+	Symbol      string    `json:"symbol" db:"symbol"`             // Currency sign, empty if unknown
+	MinorUnits  int32     `json:"minor_units" db:"minor_units"`   // Number of digits after the decimal separator
+	Enabled     bool      `json:"enabled" db:"enabled"`           // Whether the service accepts the currency
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`     // Record creation date and time
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`     // Record last update date and time
+}
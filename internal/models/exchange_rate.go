@@ -1,18 +1,72 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
+// Limits of a stored rate (DECIMAL(18,6)).
+const (
+	RateScale         = 6  // decimal places
+	RateIntegerDigits = 12 // digits before the decimal point
+)
+
+// MaxRate is the smallest rate that does not fit a stored rate.
+var MaxRate = decimal.New(1, RateIntegerDigits)
+
+// ValidateRate checks that a positive rate fits DECIMAL(18,6) exactly,
+// so that it is neither rounded nor rejected by the database.
+func ValidateRate(rate decimal.Decimal) error {
+	if !rate.IsPositive() {
+		return fmt.Errorf("rate must be positive: %s", rate)
+	}
+	if !rate.Equal(rate.Truncate(RateScale)) {
+		return fmt.Errorf("rate has more than %d decimal places: %s", RateScale, rate)
+	}
+	if rate.GreaterThanOrEqual(MaxRate) {
+		return fmt.Errorf("rate must be less than %s: %s", MaxRate, rate)
+	}
+	return nil
+}
+
 // ExchangeRateDB describes the model of a currency exchange rate record
 // stored in the database.
 type ExchangeRateDB struct {
-	ExchangeRateID uuid.UUID `json:"exchange_rate_id" db:"exchange_rate_id"` // Unique identifier of the exchange rate (UUID)
-	FromCurrency   string    `json:"from_currency" db:"from_currency"`       // Source currency
-	ToCurrency     string    `json:"to_currency" db:"to_currency"`           // Target currency
-	Rate           float64   `json:"rate" db:"rate"`                         // Exchange rate value (DECIMAL(18,6))
-	CreatedAt      time.Time `json:"created_at" db:"created_at"`             // Record creation date and time
-	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`             // Record last update date and time
+	ExchangeRateID uuid.UUID       `json:"exchange_rate_id" db:"exchange_rate_id"` // Unique identifier of the exchange rate (UUID)
+	FromCurrency   string          `json:"from_currency" db:"from_currency"`       // Source currency
+	ToCurrency     string          `json:"to_currency" db:"to_currency"`           // Target currency
+	Rate           decimal.Decimal `json:"rate" db:"rate"`                         // Exchange rate value (DECIMAL(18,6))
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`             // Record creation date and time
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`             // Record last update date and time
+}
+
+// ExchangeRatePointDB describes the last exchange rate of a currency pair
+// within a time bucket of the rate history.
+type ExchangeRatePointDB struct {
+	Bucket time.Time       `json:"bucket" db:"bucket"` // Start of the time bucket
+	Rate   decimal.Decimal `json:"rate" db:"rate"`     // Last rate effective within the bucket
+}
+
+// ExchangeRateCandleDB describes open, high, low and close rates of a currency pair
+// within a time bucket of the rate history.
+type ExchangeRateCandleDB struct {
+	Bucket  time.Time       `json:"bucket" db:"bucket"`     // Start of the time bucket
+	Open    decimal.Decimal `json:"open" db:"open"`         // First rate effective within the bucket
+	High    decimal.Decimal `json:"high" db:"high"`         // Highest rate within the bucket
+	Low     decimal.Decimal `json:"low" db:"low"`           // Lowest rate within the bucket
+	Close   decimal.Decimal `json:"close" db:"close"`       // Last rate effective within the bucket
+	Changes int64           `json:"changes" db:"changes"`   // Number of rate changes within the bucket
+	FirstAt time.Time       `json:"first_at" db:"first_at"` // Time of the first rate change within the bucket
+}
+
+// ExchangeRateHistoryDB describes an exchange rate of a currency pair
+// together with the instant it became effective.
+type ExchangeRateHistoryDB struct {
+	FromCurrency string          `json:"from_currency" db:"from_currency"` // Source currency
+	ToCurrency   string          `json:"to_currency" db:"to_currency"`     // Target currency
+	Rate         decimal.Decimal `json:"rate" db:"rate"`                   // Exchange rate value (DECIMAL(18,6))
+	EffectiveAt  time.Time       `json:"effective_at" db:"effective_at"`   // Instant the rate became effective
 }
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RateOverrideDB describes a manual override of a currency pair rate
+// stored in the database. It takes precedence over the stored rate until it expires.
+type RateOverrideDB struct {
+	RateOverrideID uuid.UUID       `json:"rate_override_id" db:"rate_override_id"` // Unique identifier of the override (UUID)
+	FromCurrency   string          `json:"from_currency" db:"from_currency"`       // Source currency
+	ToCurrency     string          `json:"to_currency" db:"to_currency"`           // Target currency
+	Rate           decimal.Decimal `json:"rate" db:"rate"`                         // Pinned rate value (DECIMAL(18,6))
+	Reason         string          `json:"reason" db:"reason"`                     // Why the rate is pinned
+	ExpiresAt      time.Time       `json:"expires_at" db:"expires_at"`             // Instant the override stops applying
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`             // Record creation date and time
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`             // Record last update date and time
+}
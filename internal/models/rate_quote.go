@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RateQuoteDB describes a raw quote of a rate provider used to compute a published exchange rate,
+// stored in the database for audit.
+type RateQuoteDB struct {
+	RateQuoteID    uuid.UUID       `json:"rate_quote_id" db:"rate_quote_id"`     // Unique identifier of the quote (UUID)
+	Source         string          `json:"source" db:"source"`                   // Name of the provider
+	FromCurrency   string          `json:"from_currency" db:"from_currency"`     // Source currency
+	ToCurrency     string          `json:"to_currency" db:"to_currency"`         // Target currency
+	Rate           decimal.Decimal `json:"rate" db:"rate"`                       // Rate as reported by the provider
+	QuotedAt       time.Time       `json:"quoted_at" db:"quoted_at"`             // Time the quote was fetched
+	Outlier        bool            `json:"outlier" db:"outlier"`                 // Whether the quote was rejected as an outlier
+	Method         string          `json:"method" db:"method"`                   // Aggregation method of the published rate
+	AggregatedRate decimal.Decimal `json:"aggregated_rate" db:"aggregated_rate"` // Published rate computed from the quotes
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`           // Record creation date and time
+}
@@ -0,0 +1,233 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoConsensus is returned when no quote of a pair is left to aggregate.
+var ErrNoConsensus = errors.New("no consensus rate")
+
+// minOutlierQuotes is the least number of quotes for which outliers are rejected:
+// with two quotes there is no way to tell which one is wrong.
+const minOutlierQuotes = 3
+
+// AggregationMethod defines how quotes of several providers are combined into one rate.
+type AggregationMethod string
+
+// Supported aggregation methods.
+const (
+	// AggregationMedian publishes the median of the quotes.
+	AggregationMedian AggregationMethod = "median"
+	// AggregationWeightedMean publishes the mean of the quotes weighted by provider.
+	AggregationWeightedMean AggregationMethod = "weighted_mean"
+	// AggregationPriority publishes the quote of the most preferred provider.
+	AggregationPriority AggregationMethod = "priority"
+)
+
+// ParseAggregationMethod parses an aggregation method name.
+func ParseAggregationMethod(s string) (AggregationMethod, error) {
+	switch m := AggregationMethod(s); m {
+	case AggregationMedian, AggregationWeightedMean, AggregationPriority:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown aggregation method: %s", s)
+	}
+}
+
+// ParseWeights parses provider weights in the form "cbr=2,ecb=1".
+func ParseWeights(s string) (map[string]decimal.Decimal, error) {
+	weights := make(map[string]decimal.Decimal)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		source, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid provider weight: %s", item)
+		}
+		weight, err := decimal.NewFromString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider weight: %s: %w", item, err)
+		}
+		if weight.IsNegative() {
+			return nil, fmt.Errorf("provider weight must not be negative: %s", item)
+		}
+		weights[strings.TrimSpace(source)] = weight
+	}
+	return weights, nil
+}
+
+// Quote is a rate of a currency pair reported by a provider.
+type Quote struct {
+	Source       string
+	FromCurrency string
+	ToCurrency   string
+	Rate         decimal.Decimal
+	QuotedAt     time.Time
+	Outlier      bool // set by Aggregator when the quote is rejected
+}
+
+// Aggregator combines quotes of a currency pair reported by several providers into one rate.
+type Aggregator struct {
+	method       AggregationMethod
+	weights      map[string]decimal.Decimal // providers missing here weigh 1
+	priority     []string                   // providers missing here follow in name order
+	maxDeviation decimal.Decimal            // relative deviation from the median; zero disables outlier rejection
+	maxAge       time.Duration              // age after which quotes are ignored; zero disables
+}
+
+// AggregatorOption configures an Aggregator.
+type AggregatorOption func(*Aggregator)
+
+// WithWeights sets the provider weights used by AggregationWeightedMean.
+func WithWeights(weights map[string]decimal.Decimal) AggregatorOption {
+	return func(a *Aggregator) {
+		a.weights = weights
+	}
+}
+
+// WithPriority sets the provider order used by AggregationPriority.
+func WithPriority(sources []string) AggregatorOption {
+	return func(a *Aggregator) {
+		a.priority = sources
+	}
+}
+
+// WithOutlierRejection rejects quotes deviating from the median of all quotes of a pair
+// by more than maxDeviation relative to it, e.g. 0.05 for 5%.
+// Outliers are only rejected when a pair has at least three quotes.
+func WithOutlierRejection(maxDeviation decimal.Decimal) AggregatorOption {
+	return func(a *Aggregator) {
+		a.maxDeviation = maxDeviation
+	}
+}
+
+// WithMaxQuoteAge ignores quotes older than maxAge, so that a provider that stopped
+// reporting a pair does not affect its rate forever.
+func WithMaxQuoteAge(maxAge time.Duration) AggregatorOption {
+	return func(a *Aggregator) {
+		a.maxAge = maxAge
+	}
+}
+
+// NewAggregator creates a new aggregator using the method.
+func NewAggregator(method AggregationMethod, opts ...AggregatorOption) *Aggregator {
+	a := &Aggregator{method: method}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Method returns the aggregation method.
+func (a *Aggregator) Method() AggregationMethod {
+	return a.method
+}
+
+// MaxQuoteAge returns the age after which quotes are ignored; zero means quotes never expire.
+func (a *Aggregator) MaxQuoteAge() time.Duration {
+	return a.maxAge
+}
+
+// Aggregate computes the rate of a pair from its quotes as of now. It returns the rate together
+// with the quotes it was computed from ordered by source, outliers flagged; stale quotes are dropped.
+func (a *Aggregator) Aggregate(quotes []Quote, now time.Time) (decimal.Decimal, []Quote, error) {
+	used := make([]Quote, 0, len(quotes))
+	for _, q := range quotes {
+		if a.maxAge > 0 && now.Sub(q.QuotedAt) > a.maxAge {
+			continue
+		}
+		q.Outlier = false
+		used = append(used, q)
+	}
+	if len(used) == 0 {
+		return decimal.Zero, nil, fmt.Errorf("%w: no fresh quotes", ErrNoConsensus)
+	}
+	sort.Slice(used, func(i, j int) bool {
+		return used[i].Source < used[j].Source
+	})
+
+	accepted := used
+	if a.maxDeviation.IsPositive() && len(used) >= minOutlierQuotes {
+		median := medianRate(used)
+		accepted = make([]Quote, 0, len(used))
+		for i, q := range used {
+			if q.Rate.Sub(median).Abs().Div(median).GreaterThan(a.maxDeviation) {
+				used[i].Outlier = true
+				continue
+			}
+			accepted = append(accepted, q)
+		}
+		if len(accepted) == 0 {
+			return decimal.Zero, used, fmt.Errorf("%w: all %d quotes deviate from median %s", ErrNoConsensus, len(used), median)
+		}
+	}
+
+	switch a.method {
+	case AggregationWeightedMean:
+		sum, total := decimal.Zero, decimal.Zero
+		for _, q := range accepted {
+			w := a.weight(q.Source)
+			sum = sum.Add(q.Rate.Mul(w))
+			total = total.Add(w)
+		}
+		if !total.IsPositive() {
+			return decimal.Zero, used, fmt.Errorf("%w: zero total weight", ErrNoConsensus)
+		}
+		return sum.Div(total), used, nil
+
+	case AggregationPriority:
+		best := accepted[0]
+		for _, q := range accepted[1:] {
+			if a.rank(q.Source) < a.rank(best.Source) {
+				best = q
+			}
+		}
+		return best.Rate, used, nil
+
+	default:
+		return medianRate(accepted), used, nil
+	}
+}
+
+// weight returns the weight of a provider.
+func (a *Aggregator) weight(source string) decimal.Decimal {
+	if w, ok := a.weights[source]; ok {
+		return w
+	}
+	return decimal.NewFromInt(1)
+}
+
+// rank returns the position of a provider in the priority list; unlisted providers rank last.
+func (a *Aggregator) rank(source string) int {
+	for i, s := range a.priority {
+		if s == source {
+			return i
+		}
+	}
+	return len(a.priority)
+}
+
+// medianRate returns the median rate of non-empty quotes.
+func medianRate(quotes []Quote) decimal.Decimal {
+	rates := make([]decimal.Decimal, 0, len(quotes))
+	for _, q := range quotes {
+		rates = append(rates, q.Rate)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].LessThan(rates[j])
+	})
+
+	mid := len(rates) / 2
+	if len(rates)%2 == 1 {
+		return rates[mid]
+	}
+	return rates[mid-1].Add(rates[mid]).Div(decimal.NewFromInt(2))
+}
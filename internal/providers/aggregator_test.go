@@ -0,0 +1,157 @@
+package providers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregatorAggregate(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	quote := func(source, rate string, age time.Duration) Quote {
+		return Quote{
+			Source:       source,
+			FromCurrency: "USD",
+			ToCurrency:   "RUB",
+			Rate:         decimal.RequireFromString(rate),
+			QuotedAt:     now.Add(-age),
+		}
+	}
+	deviation := WithOutlierRejection(decimal.RequireFromString("0.05"))
+
+	testCases := []struct {
+		name             string
+		aggregator       *Aggregator
+		quotes           []Quote
+		expectedRate     string
+		expectedSources  []string
+		expectedOutliers []string
+		expectedErr      error
+	}{
+		{
+			name:            "median of odd number of quotes",
+			aggregator:      NewAggregator(AggregationMedian),
+			quotes:          []Quote{quote("ecb", "93", 0), quote("cbr", "92", 0), quote("moex", "95", 0)},
+			expectedRate:    "93",
+			expectedSources: []string{"cbr", "ecb", "moex"},
+		},
+		{
+			name:            "median of even number of quotes",
+			aggregator:      NewAggregator(AggregationMedian),
+			quotes:          []Quote{quote("ecb", "93", 0), quote("cbr", "92", 0)},
+			expectedRate:    "92.5",
+			expectedSources: []string{"cbr", "ecb"},
+		},
+		{
+			name:            "weighted mean",
+			aggregator:      NewAggregator(AggregationWeightedMean, WithWeights(map[string]decimal.Decimal{"cbr": decimal.NewFromInt(3)})),
+			quotes:          []Quote{quote("ecb", "96", 0), quote("cbr", "92", 0)},
+			expectedRate:    "93",
+			expectedSources: []string{"cbr", "ecb"},
+		},
+		{
+			name:        "weighted mean of zero weights",
+			aggregator:  NewAggregator(AggregationWeightedMean, WithWeights(map[string]decimal.Decimal{"cbr": decimal.Zero})),
+			quotes:      []Quote{quote("cbr", "92", 0)},
+			expectedErr: ErrNoConsensus,
+		},
+		{
+			name:            "priority picks the most preferred source",
+			aggregator:      NewAggregator(AggregationPriority, WithPriority([]string{"moex", "cbr"})),
+			quotes:          []Quote{quote("ecb", "93", 0), quote("cbr", "92", 0)},
+			expectedRate:    "92",
+			expectedSources: []string{"cbr", "ecb"},
+		},
+		{
+			name:            "priority falls back to source name order",
+			aggregator:      NewAggregator(AggregationPriority),
+			quotes:          []Quote{quote("ecb", "93", 0), quote("cbr", "92", 0)},
+			expectedRate:    "92",
+			expectedSources: []string{"cbr", "ecb"},
+		},
+		{
+			name:             "outliers are rejected",
+			aggregator:       NewAggregator(AggregationPriority, WithPriority([]string{"moex"}), deviation),
+			quotes:           []Quote{quote("ecb", "93", 0), quote("cbr", "92", 0), quote("moex", "120", 0)},
+			expectedRate:     "92",
+			expectedSources:  []string{"cbr", "ecb", "moex"},
+			expectedOutliers: []string{"moex"},
+		},
+		{
+			name:            "outliers are not rejected from two quotes",
+			aggregator:      NewAggregator(AggregationMedian, deviation),
+			quotes:          []Quote{quote("cbr", "92", 0), quote("moex", "120", 0)},
+			expectedRate:    "106",
+			expectedSources: []string{"cbr", "moex"},
+		},
+		{
+			name:        "all quotes are outliers",
+			aggregator:  NewAggregator(AggregationMedian, deviation),
+			quotes:      []Quote{quote("a", "1", 0), quote("b", "1", 0), quote("c", "2", 0), quote("d", "2", 0)},
+			expectedErr: ErrNoConsensus,
+		},
+		{
+			name:            "stale quotes are dropped",
+			aggregator:      NewAggregator(AggregationMedian, WithMaxQuoteAge(time.Hour)),
+			quotes:          []Quote{quote("ecb", "93", 2*time.Hour), quote("cbr", "92", time.Minute)},
+			expectedRate:    "92",
+			expectedSources: []string{"cbr"},
+		},
+		{
+			name:        "no fresh quotes",
+			aggregator:  NewAggregator(AggregationMedian, WithMaxQuoteAge(time.Hour)),
+			quotes:      []Quote{quote("ecb", "93", 2*time.Hour)},
+			expectedErr: ErrNoConsensus,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rate, used, err := tc.aggregator.Aggregate(tc.quotes, now)
+
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRate, rate.String())
+
+			var sources, outliers []string
+			for _, q := range used {
+				sources = append(sources, q.Source)
+				if q.Outlier {
+					outliers = append(outliers, q.Source)
+				}
+			}
+			assert.Equal(t, tc.expectedSources, sources)
+			assert.Equal(t, tc.expectedOutliers, outliers)
+		})
+	}
+}
+
+func TestParseWeights(t *testing.T) {
+	weights, err := ParseWeights(" cbr=2, ecb = 0.5 ,")
+	require.NoError(t, err)
+	assert.Equal(t, "2", weights["cbr"].String())
+	assert.Equal(t, "0.5", weights["ecb"].String())
+
+	for _, s := range []string{"cbr", "cbr=x", "cbr=-1"} {
+		_, err := ParseWeights(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseAggregationMethod(t *testing.T) {
+	for _, s := range []string{"median", "weighted_mean", "priority"} {
+		m, err := ParseAggregationMethod(s)
+		require.NoError(t, err)
+		assert.Equal(t, AggregationMethod(s), m)
+	}
+
+	_, err := ParseAggregationMethod("mean")
+	assert.Error(t, err)
+}
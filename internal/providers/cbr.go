@@ -0,0 +1,109 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// CBRBaseURL is the base URL of the Central Bank of Russia website.
+const CBRBaseURL = "https://www.cbr.ru"
+
+// cbrDailyPath is the path of the daily official rates of foreign currencies against RUB.
+const cbrDailyPath = "/scripts/XML_daily.asp"
+
+// cbrValCurs is the XML_daily document: ValCurs > Valute[CharCode, Nominal, Value].
+type cbrValCurs struct {
+	Date    string `xml:"Date,attr"`
+	Valutes []struct {
+		CharCode string `xml:"CharCode"`
+		Nominal  string `xml:"Nominal"`
+		Value    string `xml:"Value"`
+	} `xml:"Valute"`
+}
+
+// CBRProvider fetches the daily official rates of the Central Bank of Russia.
+// Rates are quoted against RUB per unit of a foreign currency, e.g. USD -> RUB.
+type CBRProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewCBRProvider creates a new CBR provider. baseURL is usually CBRBaseURL.
+func NewCBRProvider(client *http.Client, baseURL string) *CBRProvider {
+	return &CBRProvider{
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Name returns the name of the provider.
+func (p *CBRProvider) Name() string {
+	return "cbr"
+}
+
+// Fetch returns the official rates for the current day.
+func (p *CBRProvider) Fetch(ctx context.Context) ([]Rate, error) {
+	body, err := httpGet(ctx, p.client, p.baseURL+cbrDailyPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseCBRRates(body)
+}
+
+// parseCBRRates parses an XML_daily document. Values use a decimal comma and are quoted
+// for Nominal units of a currency (e.g. 100 JPY), so they are divided by Nominal.
+func parseCBRRates(body []byte) ([]Rate, error) {
+	var valCurs cbrValCurs
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.CharsetReader = cbrCharsetReader
+	if err := dec.Decode(&valCurs); err != nil {
+		return nil, fmt.Errorf("parse cbr rates: %w", err)
+	}
+	if len(valCurs.Valutes) == 0 {
+		return nil, fmt.Errorf("parse cbr rates: no rates")
+	}
+
+	rates := make([]Rate, 0, len(valCurs.Valutes))
+	for _, v := range valCurs.Valutes {
+		value, err := parseCBRDecimal(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("parse cbr rates: %s on %s: value: %w", v.CharCode, valCurs.Date, err)
+		}
+		nominal, err := parseCBRDecimal(v.Nominal)
+		if err != nil {
+			return nil, fmt.Errorf("parse cbr rates: %s on %s: nominal: %w", v.CharCode, valCurs.Date, err)
+		}
+		if !nominal.IsPositive() {
+			return nil, fmt.Errorf("parse cbr rates: %s on %s: nominal must be positive: %s", v.CharCode, valCurs.Date, nominal)
+		}
+		rates = append(rates, Rate{
+			FromCurrency: v.CharCode,
+			ToCurrency:   "RUB",
+			Rate:         value.Div(nominal),
+		})
+	}
+
+	return rates, nil
+}
+
+// parseCBRDecimal parses a decimal number with a decimal comma, e.g. "101,6797".
+func parseCBRDecimal(s string) (decimal.Decimal, error) {
+	return decimal.NewFromString(strings.ReplaceAll(strings.TrimSpace(s), ",", "."))
+}
+
+// cbrCharsetReader decodes the windows-1251 documents published by the CBR.
+func cbrCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "windows-1251", "cp1251":
+		return charmap.Windows1251.NewDecoder().Reader(input), nil
+	}
+	return nil, fmt.Errorf("unsupported charset: %s", label)
+}
@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBRProviderFetch(t *testing.T) {
+	srv := serveFixture(t, cbrDailyPath, "XML_daily.xml")
+	provider := NewCBRProvider(srv.Client(), srv.URL)
+
+	rates, err := provider.Fetch(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "cbr", provider.Name())
+	require.Len(t, rates, 10)
+
+	byCurrency := make(map[string]string, len(rates))
+	for _, r := range rates {
+		assert.Equal(t, "RUB", r.ToCurrency)
+		byCurrency[r.FromCurrency] = r.Rate.String()
+	}
+	assert.Equal(t, "101.6797", byCurrency["USD"])
+	assert.Equal(t, "104.5024", byCurrency["EUR"])
+	assert.Equal(t, "0.641924", byCurrency["JPY"], "rate per 100 units is divided by nominal")
+	assert.Equal(t, "0.193453", byCurrency["KZT"])
+}
+
+func TestCBRProviderFetchErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "unsupported charset",
+			body: `<?xml version="1.0" encoding="koi8-r"?><ValCurs Date="10.01.2025"></ValCurs>`,
+		},
+		{
+			name: "no rates",
+			body: `<?xml version="1.0" encoding="windows-1251"?><ValCurs Date="10.01.2025"></ValCurs>`,
+		},
+		{
+			name: "malformed value",
+			body: `<ValCurs Date="10.01.2025"><Valute><CharCode>USD</CharCode><Nominal>1</Nominal><Value>n/a</Value></Valute></ValCurs>`,
+		},
+		{
+			name: "zero nominal",
+			body: `<ValCurs Date="10.01.2025"><Valute><CharCode>USD</CharCode><Nominal>0</Nominal><Value>101,6797</Value></Valute></ValCurs>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			_, err := NewCBRProvider(srv.Client(), srv.URL).Fetch(context.Background())
+			assert.Error(t, err)
+		})
+	}
+}
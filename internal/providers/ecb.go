@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ECBBaseURL is the base URL of the European Central Bank website.
+const ECBBaseURL = "https://www.ecb.europa.eu"
+
+// ecbDailyPath is the path of the daily euro foreign exchange reference rates.
+const ecbDailyPath = "/stats/eurofxref/eurofxref-daily.xml"
+
+// ecbEnvelope is the eurofxref XML document: Cube > Cube[time] > Cube[currency, rate].
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ECBProvider fetches the daily euro reference rates of the European Central Bank.
+// Rates are quoted for EUR as the base currency, e.g. EUR -> USD.
+type ECBProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewECBProvider creates a new ECB provider. baseURL is usually ECBBaseURL.
+func NewECBProvider(client *http.Client, baseURL string) *ECBProvider {
+	return &ECBProvider{
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Name returns the name of the provider.
+func (p *ECBProvider) Name() string {
+	return "ecb"
+}
+
+// Fetch returns the latest euro reference rates.
+func (p *ECBProvider) Fetch(ctx context.Context) ([]Rate, error) {
+	body, err := httpGet(ctx, p.client, p.baseURL+ecbDailyPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseECBRates(body)
+}
+
+// parseECBRates parses a eurofxref document. Documents with several days are ordered
+// from the latest day, so only the first day is used.
+func parseECBRates(body []byte) ([]Rate, error) {
+	var envelope ecbEnvelope
+	if err := xml.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("parse ecb rates: %w", err)
+	}
+	if len(envelope.Days) == 0 {
+		return nil, fmt.Errorf("parse ecb rates: no reference rates")
+	}
+
+	day := envelope.Days[0]
+	rates := make([]Rate, 0, len(day.Rates))
+	for _, r := range day.Rates {
+		rate, err := decimal.NewFromString(r.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("parse ecb rates: %s on %s: %w", r.Currency, day.Time, err)
+		}
+		rates = append(rates, Rate{
+			FromCurrency: "EUR",
+			ToCurrency:   r.Currency,
+			Rate:         rate,
+		})
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,84 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveFixture starts a server responding to path with the fixture file from testdata.
+func serveFixture(t *testing.T, path, fixture string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/"+fixture)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestECBProviderFetch(t *testing.T) {
+	srv := serveFixture(t, ecbDailyPath, "eurofxref-daily.xml")
+	provider := NewECBProvider(srv.Client(), srv.URL+"/")
+
+	rates, err := provider.Fetch(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "ecb", provider.Name())
+	require.Len(t, rates, 30)
+	for _, r := range rates {
+		assert.Equal(t, "EUR", r.FromCurrency)
+	}
+	assert.Equal(t, "USD", rates[0].ToCurrency)
+	assert.Equal(t, "1.0304", rates[0].Rate.String())
+	assert.Equal(t, "JPY", rates[1].ToCurrency)
+	assert.Equal(t, "162.88", rates[1].Rate.String())
+}
+
+func TestECBProviderFetchErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "unexpected status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			},
+		},
+		{
+			name: "malformed document",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("<gesmes:Envelope><Cube>"))
+			},
+		},
+		{
+			name: "no reference rates",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("<Envelope><Cube></Cube></Envelope>"))
+			},
+		},
+		{
+			name: "malformed rate",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("<Envelope><Cube><Cube time='2025-01-10'><Cube currency='USD' rate='n/a'/></Cube></Cube></Envelope>"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(tc.handler)
+			defer srv.Close()
+
+			_, err := NewECBProvider(srv.Client(), srv.URL).Fetch(context.Background())
+			assert.Error(t, err)
+		})
+	}
+}
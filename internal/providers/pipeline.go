@@ -0,0 +1,247 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// RateReader is an interface for reading the stored rate book.
+type RateReader interface {
+	List(ctx context.Context) ([]models.ExchangeRateDB, error)
+}
+
+// RateWriter is an interface for storing exchange rates together with the quotes
+// they were computed from in a single transaction.
+type RateWriter interface {
+	SaveAllWithQuotes(
+		ctx context.Context,
+		rates []models.ExchangeRateDB,
+		quotes []models.RateQuoteDB,
+	) ([]models.ExchangeRateDB, error)
+}
+
+// QuoteReader is an interface for reading the recorded provider quotes.
+type QuoteReader interface {
+	ListLatest(ctx context.Context, since time.Time) ([]models.RateQuoteDB, error)
+}
+
+// CurrencyChecker reports whether a currency is known and enabled.
+type CurrencyChecker interface {
+	Supported(code string) bool
+}
+
+// Pipeline validates rates fetched from providers, aggregates them with the latest recorded
+// quotes of other providers into one rate per pair and stores the changed ones.
+// Rates equal to the stored ones are not written, so that polling a provider
+// does not grow the rate history with duplicates. Every fetched quote is recorded for audit
+// together with the rate aggregated from it, and the recorded quotes are what later runs
+// aggregate with, so that the consensus survives restarts and is shared by replicas.
+type Pipeline struct {
+	reader     RateReader
+	quotes     QuoteReader
+	writer     RateWriter
+	currencies CurrencyChecker
+	aggregator *Aggregator
+	now        func() time.Time
+	log        *zap.SugaredLogger
+
+	mu sync.Mutex // serializes runs, so that a run aggregates the quotes recorded by the previous one
+}
+
+// NewPipeline creates a new pipeline writing through the writer.
+func NewPipeline(
+	log *zap.SugaredLogger,
+	reader RateReader,
+	quotes QuoteReader,
+	writer RateWriter,
+	currencies CurrencyChecker,
+	aggregator *Aggregator,
+) *Pipeline {
+	return &Pipeline{
+		reader:     reader,
+		quotes:     quotes,
+		writer:     writer,
+		currencies: currencies,
+		aggregator: aggregator,
+		now:        time.Now,
+		log:        log,
+	}
+}
+
+// Process stores the rates fetched from a source. Rates of unsupported currencies are skipped,
+// since providers usually quote more currencies than are enabled, and invalid rates are skipped
+// with a warning; for duplicate pairs the last rate wins. Every pair the source quotes is aggregated
+// with the latest recorded quotes of other sources, and the fetched quotes replace the recorded
+// ones of the source. Aggregated rates are rounded to the stored scale before comparison.
+func (p *Pipeline) Process(ctx context.Context, source string, rates []Rate) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+
+	fetched := make(map[string]Quote, len(rates))
+	for _, r := range rates {
+		if !p.currencies.Supported(r.FromCurrency) || !p.currencies.Supported(r.ToCurrency) {
+			p.log.Debugf("op: process rates, source: %s, skipped unsupported pair: %s -> %s", source, r.FromCurrency, r.ToCurrency)
+			continue
+		}
+		if err := validateRate(r.FromCurrency, r.ToCurrency, r.Rate); err != nil {
+			p.log.Warnf("op: process rates, source: %s, skipped: %v", source, err)
+			continue
+		}
+		fetched[pairKey(r.FromCurrency, r.ToCurrency)] = Quote{
+			Source:       source,
+			FromCurrency: r.FromCurrency,
+			ToCurrency:   r.ToCurrency,
+			Rate:         r.Rate,
+			QuotedAt:     now,
+		}
+	}
+	if len(fetched) == 0 {
+		p.log.Warnf("op: process rates, source: %s, no supported rates of %d", source, len(rates))
+		return nil
+	}
+
+	byPair, err := p.pairQuotes(ctx, source, fetched, now)
+	if err != nil {
+		p.log.Errorf("op: process rates, source: %s, err: %v", source, err)
+		return err
+	}
+
+	aggregated := make(map[string]models.ExchangeRateDB, len(byPair))
+	used := make(map[string][]Quote, len(byPair))
+	for key, quotes := range byPair {
+		rate, pairQuotes, err := p.aggregator.Aggregate(quotes, now)
+		if err != nil {
+			p.log.Warnf("op: process rates, source: %s, skipped %s: %v", source, key, err)
+			continue
+		}
+		rate = rate.Round(models.RateScale)
+		if err := validateRate(quotes[0].FromCurrency, quotes[0].ToCurrency, rate); err != nil {
+			p.log.Warnf("op: process rates, source: %s, skipped: %v", source, err)
+			continue
+		}
+		aggregated[key] = models.ExchangeRateDB{
+			FromCurrency: quotes[0].FromCurrency,
+			ToCurrency:   quotes[0].ToCurrency,
+			Rate:         rate,
+		}
+		used[key] = pairQuotes
+	}
+
+	keys := make([]string, 0, len(aggregated))
+	for key := range aggregated {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Every fetched quote is recorded with the rate aggregated from it, changed or not.
+	quotes := make([]models.RateQuoteDB, 0, len(keys))
+	for _, key := range keys {
+		for _, q := range used[key] {
+			if q.Source != source {
+				continue
+			}
+			quotes = append(quotes, models.RateQuoteDB{
+				Source:         q.Source,
+				FromCurrency:   q.FromCurrency,
+				ToCurrency:     q.ToCurrency,
+				Rate:           q.Rate,
+				QuotedAt:       q.QuotedAt,
+				Outlier:        q.Outlier,
+				Method:         string(p.aggregator.Method()),
+				AggregatedRate: aggregated[key].Rate,
+			})
+		}
+	}
+
+	stored, err := p.reader.List(ctx)
+	if err != nil {
+		p.log.Errorf("op: process rates, source: %s, err: %v", source, err)
+		return err
+	}
+	for _, s := range stored {
+		key := pairKey(s.FromCurrency, s.ToCurrency)
+		if r, ok := aggregated[key]; ok && r.Rate.Equal(s.Rate) {
+			delete(aggregated, key)
+		}
+	}
+
+	changed := make([]models.ExchangeRateDB, 0, len(aggregated))
+	for _, key := range keys {
+		if r, ok := aggregated[key]; ok {
+			changed = append(changed, r)
+		}
+	}
+
+	if len(quotes) > 0 {
+		if _, err := p.writer.SaveAllWithQuotes(ctx, changed, quotes); err != nil {
+			p.log.Errorf("op: process rates, source: %s, err: %v", source, err)
+			return err
+		}
+	}
+	p.log.Infof("op: process rates, source: %s, received: %d, changed: %d", source, len(rates), len(changed))
+
+	return nil
+}
+
+// pairQuotes returns the fetched quotes of a source together with the latest recorded quotes
+// of other sources for the same pairs. Recorded quotes older than the maximum quote age are not read.
+func (p *Pipeline) pairQuotes(
+	ctx context.Context,
+	source string,
+	fetched map[string]Quote,
+	now time.Time,
+) (map[string][]Quote, error) {
+	var since time.Time
+	if maxAge := p.aggregator.MaxQuoteAge(); maxAge > 0 {
+		since = now.Add(-maxAge)
+	}
+	recorded, err := p.quotes.ListLatest(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byPair := make(map[string][]Quote, len(fetched))
+	for key, q := range fetched {
+		byPair[key] = []Quote{q}
+	}
+	for _, r := range recorded {
+		key := pairKey(r.FromCurrency, r.ToCurrency)
+		if _, ok := byPair[key]; !ok || r.Source == source {
+			continue
+		}
+		byPair[key] = append(byPair[key], Quote{
+			Source:       r.Source,
+			FromCurrency: r.FromCurrency,
+			ToCurrency:   r.ToCurrency,
+			Rate:         r.Rate,
+			QuotedAt:     r.QuotedAt,
+		})
+	}
+	return byPair, nil
+}
+
+// validateRate checks that a rate quotes two different currencies and that,
+// rounded to the stored scale, it fits a stored rate.
+func validateRate(fromCurrency, toCurrency string, rate decimal.Decimal) error {
+	if fromCurrency == toCurrency {
+		return fmt.Errorf("from and to currencies must differ: %s", fromCurrency)
+	}
+	if err := models.ValidateRate(rate.Round(models.RateScale)); err != nil {
+		return fmt.Errorf("%s -> %s: %w", fromCurrency, toCurrency, err)
+	}
+	return nil
+}
+
+// pairKey returns the map key of a currency pair.
+func pairKey(fromCurrency, toCurrency string) string {
+	return fromCurrency + ":" + toCurrency
+}
@@ -0,0 +1,165 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /home/sergey/Github/gw-exchanger/internal/providers/pipeline.go
+
+// Package providers is a generated GoMock package.
+package providers
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/sbilibin2017/gw-exchanger/internal/models"
+)
+
+// MockRateReader is a mock of RateReader interface.
+type MockRateReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateReaderMockRecorder
+}
+
+// MockRateReaderMockRecorder is the mock recorder for MockRateReader.
+type MockRateReaderMockRecorder struct {
+	mock *MockRateReader
+}
+
+// NewMockRateReader creates a new mock instance.
+func NewMockRateReader(ctrl *gomock.Controller) *MockRateReader {
+	mock := &MockRateReader{ctrl: ctrl}
+	mock.recorder = &MockRateReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateReader) EXPECT() *MockRateReaderMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockRateReader) List(ctx context.Context) ([]models.ExchangeRateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.ExchangeRateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRateReaderMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRateReader)(nil).List), ctx)
+}
+
+// MockRateWriter is a mock of RateWriter interface.
+type MockRateWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateWriterMockRecorder
+}
+
+// MockRateWriterMockRecorder is the mock recorder for MockRateWriter.
+type MockRateWriterMockRecorder struct {
+	mock *MockRateWriter
+}
+
+// NewMockRateWriter creates a new mock instance.
+func NewMockRateWriter(ctrl *gomock.Controller) *MockRateWriter {
+	mock := &MockRateWriter{ctrl: ctrl}
+	mock.recorder = &MockRateWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateWriter) EXPECT() *MockRateWriterMockRecorder {
+	return m.recorder
+}
+
+// SaveAllWithQuotes mocks base method.
+func (m *MockRateWriter) SaveAllWithQuotes(ctx context.Context, rates []models.ExchangeRateDB, quotes []models.RateQuoteDB) ([]models.ExchangeRateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAllWithQuotes", ctx, rates, quotes)
+	ret0, _ := ret[0].([]models.ExchangeRateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAllWithQuotes indicates an expected call of SaveAllWithQuotes.
+func (mr *MockRateWriterMockRecorder) SaveAllWithQuotes(ctx, rates, quotes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAllWithQuotes", reflect.TypeOf((*MockRateWriter)(nil).SaveAllWithQuotes), ctx, rates, quotes)
+}
+
+// MockQuoteReader is a mock of QuoteReader interface.
+type MockQuoteReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteReaderMockRecorder
+}
+
+// MockQuoteReaderMockRecorder is the mock recorder for MockQuoteReader.
+type MockQuoteReaderMockRecorder struct {
+	mock *MockQuoteReader
+}
+
+// NewMockQuoteReader creates a new mock instance.
+func NewMockQuoteReader(ctrl *gomock.Controller) *MockQuoteReader {
+	mock := &MockQuoteReader{ctrl: ctrl}
+	mock.recorder = &MockQuoteReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteReader) EXPECT() *MockQuoteReaderMockRecorder {
+	return m.recorder
+}
+
+// ListLatest mocks base method.
+func (m *MockQuoteReader) ListLatest(ctx context.Context, since time.Time) ([]models.RateQuoteDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLatest", ctx, since)
+	ret0, _ := ret[0].([]models.RateQuoteDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLatest indicates an expected call of ListLatest.
+func (mr *MockQuoteReaderMockRecorder) ListLatest(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLatest", reflect.TypeOf((*MockQuoteReader)(nil).ListLatest), ctx, since)
+}
+
+// MockCurrencyChecker is a mock of CurrencyChecker interface.
+type MockCurrencyChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyCheckerMockRecorder
+}
+
+// MockCurrencyCheckerMockRecorder is the mock recorder for MockCurrencyChecker.
+type MockCurrencyCheckerMockRecorder struct {
+	mock *MockCurrencyChecker
+}
+
+// NewMockCurrencyChecker creates a new mock instance.
+func NewMockCurrencyChecker(ctrl *gomock.Controller) *MockCurrencyChecker {
+	mock := &MockCurrencyChecker{ctrl: ctrl}
+	mock.recorder = &MockCurrencyCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyChecker) EXPECT() *MockCurrencyCheckerMockRecorder {
+	return m.recorder
+}
+
+// Supported mocks base method.
+func (m *MockCurrencyChecker) Supported(code string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Supported", code)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Supported indicates an expected call of Supported.
+func (mr *MockCurrencyCheckerMockRecorder) Supported(code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Supported", reflect.TypeOf((*MockCurrencyChecker)(nil).Supported), code)
+}
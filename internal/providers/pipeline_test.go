@@ -0,0 +1,289 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// supportedCurrencies is a CurrencyChecker over a fixed set of codes.
+type supportedCurrencies map[string]bool
+
+func (c supportedCurrencies) Supported(code string) bool { return c[code] }
+
+func testRate(from, to, rate string) Rate {
+	return Rate{FromCurrency: from, ToCurrency: to, Rate: decimal.RequireFromString(rate)}
+}
+
+func storedRate(from, to, rate string) models.ExchangeRateDB {
+	return models.ExchangeRateDB{FromCurrency: from, ToCurrency: to, Rate: decimal.RequireFromString(rate)}
+}
+
+var testNow = time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func recordedQuote(source, from, to, rate string) models.RateQuoteDB {
+	return models.RateQuoteDB{Source: source, FromCurrency: from, ToCurrency: to, Rate: decimal.RequireFromString(rate), QuotedAt: testNow.Add(-time.Hour)}
+}
+
+// sourceRates is a batch of rates fetched from a source.
+type sourceRates struct {
+	source string
+	rates  []Rate
+}
+
+// recordSaved expects a single write and records the saved rates and quotes as strings.
+func recordSaved(w *MockRateWriter, saved, quotes *[]string) {
+	w.EXPECT().SaveAllWithQuotes(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, rates []models.ExchangeRateDB, qs []models.RateQuoteDB) ([]models.ExchangeRateDB, error) {
+			for _, r := range rates {
+				*saved = append(*saved, pairKey(r.FromCurrency, r.ToCurrency)+"="+r.Rate.String())
+			}
+			for _, q := range qs {
+				*quotes = append(*quotes, fmt.Sprintf("%s %s=%s outlier=%t %s=%s",
+					q.Source, pairKey(q.FromCurrency, q.ToCurrency), q.Rate, q.Outlier, q.Method, q.AggregatedRate))
+			}
+			return rates, nil
+		})
+}
+
+func TestPipelineProcess(t *testing.T) {
+	testCases := []struct {
+		name           string
+		aggregator     *Aggregator
+		batch          sourceRates
+		mockSetup      func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string)
+		expectedSaved  []string
+		expectedQuotes []string
+		expectedErr    bool
+	}{
+		{
+			name:  "changed rates are saved ordered by pair",
+			batch: sourceRates{"cbr", []Rate{testRate("USD", "RUB", "92.5"), testRate("EUR", "USD", "1.08")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), time.Time{}).Return(nil, nil)
+				r.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{storedRate("USD", "RUB", "91")}, nil)
+				recordSaved(w, saved, quotes)
+			},
+			expectedSaved: []string{"EUR:USD=1.08", "USD:RUB=92.5"},
+			expectedQuotes: []string{
+				"cbr EUR:USD=1.08 outlier=false median=1.08",
+				"cbr USD:RUB=92.5 outlier=false median=92.5",
+			},
+		},
+		{
+			name:  "unchanged rates are not saved but their quotes are recorded",
+			batch: sourceRates{"cbr", []Rate{testRate("USD", "RUB", "91.0000001"), testRate("EUR", "USD", "1.08")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), time.Time{}).Return(nil, nil)
+				r.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+					storedRate("USD", "RUB", "91"),
+					storedRate("EUR", "USD", "1.08"),
+				}, nil)
+				recordSaved(w, saved, quotes)
+			},
+			expectedQuotes: []string{
+				"cbr EUR:USD=1.08 outlier=false median=1.08",
+				"cbr USD:RUB=91.0000001 outlier=false median=91",
+			},
+		},
+		{
+			name: "unsupported and invalid rates are skipped",
+			batch: sourceRates{"cbr", []Rate{
+				testRate("USD", "GBP", "0.79"),
+				testRate("USD", "USD", "1"),
+				testRate("EUR", "RUB", "-1"),
+				testRate("EUR", "USD", "0.0000001"),
+				testRate("USD", "RUB", "1000000000000"),
+				testRate("RUB", "USD", "0.010869565217"),
+			}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), time.Time{}).Return(nil, nil)
+				r.EXPECT().List(gomock.Any()).Return(nil, nil)
+				recordSaved(w, saved, quotes)
+			},
+			expectedSaved:  []string{"RUB:USD=0.01087"},
+			expectedQuotes: []string{"cbr RUB:USD=0.010869565217 outlier=false median=0.01087"},
+		},
+		{
+			name:  "last rate of a duplicate pair wins",
+			batch: sourceRates{"cbr", []Rate{testRate("USD", "RUB", "92"), testRate("USD", "RUB", "93")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), time.Time{}).Return(nil, nil)
+				r.EXPECT().List(gomock.Any()).Return(nil, nil)
+				recordSaved(w, saved, quotes)
+			},
+			expectedSaved:  []string{"USD:RUB=93"},
+			expectedQuotes: []string{"cbr USD:RUB=93 outlier=false median=93"},
+		},
+		{
+			name:  "recorded quotes of other sources are aggregated",
+			batch: sourceRates{"cbr", []Rate{testRate("USD", "RUB", "93")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), time.Time{}).Return([]models.RateQuoteDB{
+					recordedQuote("cbr", "USD", "RUB", "80"),
+					recordedQuote("ecb", "USD", "RUB", "92"),
+					recordedQuote("moex", "USD", "RUB", "120"),
+				}, nil)
+				r.EXPECT().List(gomock.Any()).Return(nil, nil)
+				recordSaved(w, saved, quotes)
+			},
+			aggregator:     NewAggregator(AggregationWeightedMean, WithOutlierRejection(decimal.RequireFromString("0.05"))),
+			expectedSaved:  []string{"USD:RUB=92.5"},
+			expectedQuotes: []string{"cbr USD:RUB=93 outlier=false weighted_mean=92.5"},
+		},
+		{
+			name:  "median of two sources",
+			batch: sourceRates{"cbr", []Rate{testRate("EUR", "USD", "1.1")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), time.Time{}).Return([]models.RateQuoteDB{
+					recordedQuote("cbr", "USD", "RUB", "100"),
+					recordedQuote("ecb", "EUR", "USD", "1.08"),
+				}, nil)
+				r.EXPECT().List(gomock.Any()).Return(nil, nil)
+				recordSaved(w, saved, quotes)
+			},
+			expectedSaved:  []string{"EUR:USD=1.09"},
+			expectedQuotes: []string{"cbr EUR:USD=1.1 outlier=false median=1.09"},
+		},
+		{
+			name:      "no supported rates",
+			batch:     sourceRates{"cbr", []Rate{testRate("USD", "GBP", "0.79")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {},
+		},
+		{
+			name:  "quote reader returns error",
+			batch: sourceRates{"cbr", []Rate{testRate("USD", "RUB", "92")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+		{
+			name:  "reader returns error",
+			batch: sourceRates{"cbr", []Rate{testRate("USD", "RUB", "92")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), gomock.Any()).Return(nil, nil)
+				r.EXPECT().List(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+		{
+			name:  "writer returns error",
+			batch: sourceRates{"cbr", []Rate{testRate("USD", "RUB", "92")}},
+			mockSetup: func(q *MockQuoteReader, r *MockRateReader, w *MockRateWriter, saved, quotes *[]string) {
+				q.EXPECT().ListLatest(gomock.Any(), gomock.Any()).Return(nil, nil)
+				r.EXPECT().List(gomock.Any()).Return(nil, nil)
+				w.EXPECT().SaveAllWithQuotes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			aggregator := tc.aggregator
+			if aggregator == nil {
+				aggregator = NewAggregator(AggregationMedian)
+			}
+			currencies := supportedCurrencies{"USD": true, "EUR": true, "RUB": true}
+
+			mockQuotes := NewMockQuoteReader(ctrl)
+			mockReader := NewMockRateReader(ctrl)
+			mockWriter := NewMockRateWriter(ctrl)
+			pipeline := NewPipeline(zap.NewNop().Sugar(), mockReader, mockQuotes, mockWriter, currencies, aggregator)
+			pipeline.now = func() time.Time { return testNow }
+
+			var saved, quotes []string
+			tc.mockSetup(mockQuotes, mockReader, mockWriter, &saved, &quotes)
+
+			err := pipeline.Process(context.Background(), tc.batch.source, tc.batch.rates)
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSaved, saved)
+			assert.Equal(t, tc.expectedQuotes, quotes)
+		})
+	}
+}
+
+func TestPipelineProcessStaleQuotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stale := recordedQuote("ecb", "USD", "RUB", "100")
+	stale.QuotedAt = testNow.Add(-2 * time.Hour)
+
+	mockQuotes := NewMockQuoteReader(ctrl)
+	mockQuotes.EXPECT().ListLatest(gomock.Any(), testNow.Add(-time.Hour)).Return([]models.RateQuoteDB{stale}, nil)
+	mockReader := NewMockRateReader(ctrl)
+	mockReader.EXPECT().List(gomock.Any()).Return(nil, nil)
+	mockWriter := NewMockRateWriter(ctrl)
+	var saved, quotes []string
+	recordSaved(mockWriter, &saved, &quotes)
+
+	aggregator := NewAggregator(AggregationMedian, WithMaxQuoteAge(time.Hour))
+	currencies := supportedCurrencies{"USD": true, "RUB": true}
+	pipeline := NewPipeline(zap.NewNop().Sugar(), mockReader, mockQuotes, mockWriter, currencies, aggregator)
+	pipeline.now = func() time.Time { return testNow }
+
+	require.NoError(t, pipeline.Process(context.Background(), "cbr", []Rate{testRate("USD", "RUB", "92")}))
+
+	assert.Equal(t, []string{"USD:RUB=92"}, saved)
+	assert.Equal(t, []string{"cbr USD:RUB=92 outlier=false median=92"}, quotes)
+}
+
+func TestPipelineProcessSerialized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var running, overlaps atomic.Int32
+	mockQuotes := NewMockQuoteReader(ctrl)
+	mockQuotes.EXPECT().ListLatest(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, time.Time) ([]models.RateQuoteDB, error) {
+			if running.Add(1) > 1 {
+				overlaps.Add(1)
+			}
+			return nil, nil
+		}).Times(2)
+	mockReader := NewMockRateReader(ctrl)
+	mockReader.EXPECT().List(gomock.Any()).Return(nil, nil).Times(2)
+	mockWriter := NewMockRateWriter(ctrl)
+	mockWriter.EXPECT().SaveAllWithQuotes(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, []models.ExchangeRateDB, []models.RateQuoteDB) ([]models.ExchangeRateDB, error) {
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil, nil
+		}).Times(2)
+
+	currencies := supportedCurrencies{"USD": true, "RUB": true}
+	pipeline := NewPipeline(zap.NewNop().Sugar(), mockReader, mockQuotes, mockWriter, currencies, NewAggregator(AggregationMedian))
+
+	var wg sync.WaitGroup
+	for _, source := range []string{"cbr", "ecb"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pipeline.Process(context.Background(), source, []Rate{testRate("USD", "RUB", "92")}))
+		}()
+	}
+	wg.Wait()
+
+	assert.Zero(t, overlaps.Load(), "runs must not overlap between reading quotes and writing")
+}
@@ -0,0 +1,55 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxResponseSize is the upper bound of a provider response body.
+const maxResponseSize = 4 << 20
+
+// Rate is an exchange rate quoted by a provider: one unit of FromCurrency costs Rate units of ToCurrency.
+type Rate struct {
+	FromCurrency string
+	ToCurrency   string
+	Rate         decimal.Decimal
+}
+
+// Provider is an external source of currency exchange rates.
+type Provider interface {
+	// Name returns a short stable name of the provider used in logs.
+	Name() string
+	// Fetch returns the current rates of the provider.
+	Fetch(ctx context.Context) ([]Rate, error)
+}
+
+// httpGet requests a URL and returns the response body. Responses other than 200 OK are errors.
+func httpGet(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s: unexpected status: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", url, err)
+	}
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("get %s: response exceeds %d bytes", url, maxResponseSize)
+	}
+	return body, nil
+}
@@ -0,0 +1,122 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RateSink is an interface for consuming rates fetched from providers.
+type RateSink interface {
+	Process(ctx context.Context, source string, rates []Rate) error
+}
+
+// job is a provider scheduled with its polling interval.
+type job struct {
+	provider Provider
+	interval time.Duration
+}
+
+// Scheduler polls every provider on its own interval and passes the fetched rates to the sink.
+// Every run is delayed by a random jitter so that replicas do not hit providers at once,
+// and is bounded by the timeout covering both fetching and storing.
+type Scheduler struct {
+	sink    RateSink
+	timeout time.Duration
+	jitter  time.Duration
+	jobs    []job
+	log     *zap.SugaredLogger
+}
+
+// NewScheduler creates a new scheduler over the sink.
+// timeout bounds a single run of a provider and jitter is the upper bound of the random delay
+// added to every run; zero disables them.
+func NewScheduler(
+	log *zap.SugaredLogger,
+	sink RateSink,
+	timeout time.Duration,
+	jitter time.Duration,
+) *Scheduler {
+	return &Scheduler{
+		sink:    sink,
+		timeout: timeout,
+		jitter:  jitter,
+		log:     log,
+	}
+}
+
+// Add schedules a provider to be polled every interval. It must be called before Run.
+// A non-positive interval is rejected since it would poll the provider in a busy loop.
+func (s *Scheduler) Add(provider Provider, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("provider %s: interval must be positive, got %s", provider.Name(), interval)
+	}
+	s.jobs = append(s.jobs, job{provider: provider, interval: interval})
+	return nil
+}
+
+// Len returns the number of scheduled providers.
+func (s *Scheduler) Len() int {
+	return len(s.jobs)
+}
+
+// Run polls the providers until ctx is done. Every provider is first polled right after startup.
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, j := range s.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runJob(ctx, j)
+		}()
+	}
+	wg.Wait()
+}
+
+// runJob polls a single provider until ctx is done.
+func (s *Scheduler) runJob(ctx context.Context, j job) {
+	timer := time.NewTimer(s.delay(0))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		s.RunOnce(ctx, j.provider)
+		timer.Reset(s.delay(j.interval))
+	}
+}
+
+// RunOnce fetches the rates of a provider and passes them to the sink.
+func (s *Scheduler) RunOnce(ctx context.Context, provider Provider) error {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	rates, err := provider.Fetch(ctx)
+	if err != nil {
+		s.log.Errorf("op: fetch rates, provider: %s, err: %v", provider.Name(), err)
+		return err
+	}
+	s.log.Debugf("op: fetch rates, provider: %s, rates: %d, duration: %s", provider.Name(), len(rates), time.Since(start))
+
+	return s.sink.Process(ctx, provider.Name(), rates)
+}
+
+// delay returns the interval extended by a random jitter.
+func (s *Scheduler) delay(interval time.Duration) time.Duration {
+	if s.jitter <= 0 {
+		return interval
+	}
+	return interval + rand.N(s.jitter)
+}
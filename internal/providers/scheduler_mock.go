@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /home/sergey/Github/gw-exchanger/internal/providers/scheduler.go
+
+// Package providers is a generated GoMock package.
+package providers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRateSink is a mock of RateSink interface.
+type MockRateSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateSinkMockRecorder
+}
+
+// MockRateSinkMockRecorder is the mock recorder for MockRateSink.
+type MockRateSinkMockRecorder struct {
+	mock *MockRateSink
+}
+
+// NewMockRateSink creates a new mock instance.
+func NewMockRateSink(ctrl *gomock.Controller) *MockRateSink {
+	mock := &MockRateSink{ctrl: ctrl}
+	mock.recorder = &MockRateSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateSink) EXPECT() *MockRateSinkMockRecorder {
+	return m.recorder
+}
+
+// Process mocks base method.
+func (m *MockRateSink) Process(ctx context.Context, source string, rates []Rate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, source, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockRateSinkMockRecorder) Process(ctx, source, rates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockRateSink)(nil).Process), ctx, source, rates)
+}
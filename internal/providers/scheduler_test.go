@@ -0,0 +1,120 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSchedulerRunOnce(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mockSetup   func(p *MockProvider, s *MockRateSink)
+		expectedErr bool
+	}{
+		{
+			name: "fetched rates are passed to the sink",
+			mockSetup: func(p *MockProvider, s *MockRateSink) {
+				rates := []Rate{testRate("USD", "RUB", "92")}
+				p.EXPECT().Fetch(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]Rate, error) {
+					_, ok := ctx.Deadline()
+					assert.True(t, ok, "fetch must be bounded by the timeout")
+					return rates, nil
+				})
+				s.EXPECT().Process(gomock.Any(), "test", rates).Return(nil)
+			},
+		},
+		{
+			name: "fetch error",
+			mockSetup: func(p *MockProvider, s *MockRateSink) {
+				p.EXPECT().Fetch(gomock.Any()).Return(nil, errors.New("http error"))
+			},
+			expectedErr: true,
+		},
+		{
+			name: "sink error",
+			mockSetup: func(p *MockProvider, s *MockRateSink) {
+				p.EXPECT().Fetch(gomock.Any()).Return([]Rate{testRate("USD", "RUB", "92")}, nil)
+				s.EXPECT().Process(gomock.Any(), "test", gomock.Any()).Return(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockProvider := NewMockProvider(ctrl)
+			mockProvider.EXPECT().Name().Return("test").AnyTimes()
+			mockSink := NewMockRateSink(ctrl)
+			tc.mockSetup(mockProvider, mockSink)
+
+			scheduler := NewScheduler(zap.NewNop().Sugar(), mockSink, time.Second, 0)
+			err := scheduler.RunOnce(context.Background(), mockProvider)
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var fetches atomic.Int32
+	mockProvider := NewMockProvider(ctrl)
+	mockProvider.EXPECT().Name().Return("test").AnyTimes()
+	mockProvider.EXPECT().Fetch(gomock.Any()).DoAndReturn(func(context.Context) ([]Rate, error) {
+		if fetches.Add(1) == 3 {
+			cancel()
+		}
+		return nil, errors.New("http error")
+	}).MinTimes(3)
+
+	scheduler := NewScheduler(zap.NewNop().Sugar(), NewMockRateSink(ctrl), time.Second, time.Millisecond)
+	require.NoError(t, scheduler.Add(mockProvider, 5*time.Millisecond))
+	require.Equal(t, 1, scheduler.Len())
+
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+	assert.GreaterOrEqual(t, fetches.Load(), int32(3))
+}
+
+func TestSchedulerAddRejectsNonPositiveInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := NewMockProvider(ctrl)
+	mockProvider.EXPECT().Name().Return("test").AnyTimes()
+
+	scheduler := NewScheduler(zap.NewNop().Sugar(), NewMockRateSink(ctrl), time.Second, 0)
+	for _, interval := range []time.Duration{0, -time.Minute} {
+		assert.Error(t, scheduler.Add(mockProvider, interval))
+	}
+	assert.Equal(t, 0, scheduler.Len())
+}
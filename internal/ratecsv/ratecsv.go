@@ -0,0 +1,157 @@
+// Package ratecsv reads and writes exchange rates in CSV with the columns
+// from,to,rate,effective_at, where effective_at is an RFC 3339 timestamp.
+package ratecsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+// header is the mandatory first record of a rates file.
+var header = []string{"from", "to", "rate", "effective_at"}
+
+// ErrNoRates is returned by Read for a file without rate records.
+var ErrNoRates = errors.New("no rates")
+
+// RowError is a validation error of a single record of a rates file.
+type RowError struct {
+	Line int // line of the record in the file, starting from 1
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Read parses and validates a rates file. supported reports whether a currency is accepted,
+// and effective_at must not be later than now.
+// Records are validated independently: if any of them is invalid, Read returns
+// every RowError joined into a single error and no rates.
+func Read(r io.Reader, supported func(code string) bool, now time.Time) ([]models.ExchangeRateHistoryDB, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	first, err := cr.Read()
+	if err == io.EOF {
+		return nil, ErrNoRates
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(first, header) {
+		return nil, fmt.Errorf("unexpected header %q, want %q", first, header)
+	}
+
+	var (
+		rates   []models.ExchangeRateHistoryDB
+		rowErrs []error
+		seen    = make(map[string]int)
+	)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		rate, err := parseRecord(record, supported, now)
+		if err != nil {
+			rowErrs = append(rowErrs, &RowError{Line: line, Err: err})
+			continue
+		}
+
+		key := rate.FromCurrency + ":" + rate.ToCurrency + "@" + rate.EffectiveAt.Format(time.RFC3339Nano)
+		if prev, ok := seen[key]; ok {
+			rowErrs = append(rowErrs, &RowError{Line: line, Err: fmt.Errorf("duplicates line %d", prev)})
+			continue
+		}
+		seen[key] = line
+		rates = append(rates, rate)
+	}
+
+	if len(rowErrs) > 0 {
+		return nil, errors.Join(rowErrs...)
+	}
+	if len(rates) == 0 {
+		return nil, ErrNoRates
+	}
+
+	return rates, nil
+}
+
+// parseRecord validates a single record of a rates file.
+func parseRecord(record []string, supported func(code string) bool, now time.Time) (models.ExchangeRateHistoryDB, error) {
+	if len(record) != len(header) {
+		return models.ExchangeRateHistoryDB{}, fmt.Errorf("expected %d fields, got %d", len(header), len(record))
+	}
+	from, to, rawRate, rawEffectiveAt := record[0], record[1], record[2], record[3]
+
+	if !supported(from) {
+		return models.ExchangeRateHistoryDB{}, fmt.Errorf("unsupported currency: from %s", from)
+	}
+	if !supported(to) {
+		return models.ExchangeRateHistoryDB{}, fmt.Errorf("unsupported currency: to %s", to)
+	}
+	if from == to {
+		return models.ExchangeRateHistoryDB{}, fmt.Errorf("from and to currencies must differ: %s", from)
+	}
+
+	rate, err := decimal.NewFromString(rawRate)
+	if err != nil {
+		return models.ExchangeRateHistoryDB{}, fmt.Errorf("invalid rate %q: %w", rawRate, err)
+	}
+	if err := models.ValidateRate(rate); err != nil {
+		return models.ExchangeRateHistoryDB{}, err
+	}
+
+	effectiveAt, err := time.Parse(time.RFC3339Nano, rawEffectiveAt)
+	if err != nil {
+		return models.ExchangeRateHistoryDB{}, fmt.Errorf("invalid effective_at %q: %w", rawEffectiveAt, err)
+	}
+	if effectiveAt.After(now) {
+		return models.ExchangeRateHistoryDB{}, fmt.Errorf("effective_at is in the future: %s", rawEffectiveAt)
+	}
+
+	return models.ExchangeRateHistoryDB{
+		FromCurrency: from,
+		ToCurrency:   to,
+		Rate:         rate,
+		EffectiveAt:  effectiveAt.UTC(),
+	}, nil
+}
+
+// Write writes the rates with a header in the format accepted by Read.
+func Write(w io.Writer, rates []models.ExchangeRateHistoryDB) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range rates {
+		record := []string{
+			r.FromCurrency,
+			r.ToCurrency,
+			r.Rate.String(),
+			r.EffectiveAt.UTC().Format(time.RFC3339Nano),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,103 @@
+package ratecsv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+
+// testSupported accepts the currencies used in tests.
+func testSupported(code string) bool {
+	return code == "EUR" || code == "RUB" || code == "USD"
+}
+
+func TestRead(t *testing.T) {
+	input := "from,to,rate,effective_at\n" +
+		"USD,RUB,92.5,2025-01-15T12:00:00Z\n" +
+		"EUR,RUB,100.123456,2025-01-15T15:00:00+03:00\n"
+
+	rates, err := Read(strings.NewReader(input), testSupported, testNow)
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "USD", rates[0].FromCurrency)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(rates[0].Rate))
+	assert.Equal(t, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), rates[1].EffectiveAt, "converted to UTC")
+}
+
+func TestReadRowErrors(t *testing.T) {
+	input := "from,to,rate,effective_at\n" +
+		"USD,RUB,92.5,2025-01-15T12:00:00Z\n" +
+		"GBP,RUB,110,2025-01-15T12:00:00Z\n" +
+		"USD,USD,1,2025-01-15T12:00:00Z\n" +
+		"USD,RUB,-1,2025-01-16T12:00:00Z\n" +
+		"USD,RUB,92.1234567,2025-01-16T12:00:00Z\n" +
+		"USD,RUB,1000000000000,2025-01-16T12:00:00Z\n" +
+		"USD,RUB,abc,2025-01-16T12:00:00Z\n" +
+		"USD,RUB,92.5,2025-01-16\n" +
+		"USD,RUB,92.5,2025-02-01T00:00:00Z\n" +
+		"USD,RUB,92.5\n" +
+		"USD,RUB,93,2025-01-15T12:00:00Z\n"
+
+	rates, err := Read(strings.NewReader(input), testSupported, testNow)
+	require.Error(t, err)
+	assert.Nil(t, rates)
+
+	var lines []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var rowErr *RowError
+		require.True(t, errors.As(e, &rowErr))
+		lines = append(lines, rowErr.Line)
+	}
+	assert.Equal(t, []int{3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, lines)
+	assert.Contains(t, err.Error(), "line 3: unsupported currency: from GBP")
+	assert.Contains(t, err.Error(), "line 12: duplicates line 2")
+}
+
+func TestReadInvalidFile(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty file", input: ""},
+		{name: "header only", input: "from,to,rate,effective_at\n"},
+		{name: "unexpected header", input: "from,to,rate\nUSD,RUB,92.5\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rates, err := Read(strings.NewReader(tc.input), testSupported, testNow)
+			assert.Error(t, err)
+			assert.Nil(t, rates)
+		})
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	rates := []models.ExchangeRateHistoryDB{
+		{FromCurrency: "EUR", ToCurrency: "RUB", Rate: decimal.RequireFromString("100.123456"), EffectiveAt: time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5"), EffectiveAt: time.Date(2025, 1, 16, 9, 30, 0, 500, time.UTC)},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, rates))
+	assert.Equal(t, "from,to,rate,effective_at\n"+
+		"EUR,RUB,100.123456,2025-01-15T12:00:00Z\n"+
+		"USD,RUB,92.5,2025-01-16T09:30:00.0000005Z\n", buf.String())
+
+	got, err := Read(&buf, testSupported, testNow)
+	require.NoError(t, err)
+	require.Len(t, got, len(rates))
+	for i := range rates {
+		assert.True(t, rates[i].Rate.Equal(got[i].Rate))
+		assert.True(t, rates[i].EffectiveAt.Equal(got[i].EffectiveAt))
+	}
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"go.uber.org/zap"
+)
+
+// CurrencyRepository reads the currency reference table from the DB.
+type CurrencyRepository struct {
+	db  *sqlx.DB
+	log *zap.SugaredLogger
+}
+
+// NewCurrencyRepository creates a new repository with a logger.
+func NewCurrencyRepository(log *zap.SugaredLogger, db *sqlx.DB) *CurrencyRepository {
+	return &CurrencyRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// List returns all currency records ordered by code.
+func (r *CurrencyRepository) List(
+	ctx context.Context,
+) ([]models.CurrencyDB, error) {
+
+	query, args := buildListCurrencyQuery()
+	var currencies []models.CurrencyDB
+	err := r.db.SelectContext(ctx, &currencies, query, args...)
+	if err != nil {
+		r.log.Errorf("op: list currencies, err: %v", err)
+		return nil, err
+	}
+
+	return currencies, nil
+}
+
+// buildListCurrencyQuery returns the SQL query and empty arguments for all currencies.
+func buildListCurrencyQuery() (string, []any) {
+	query := `
+		SELECT code, numeric_code, name, symbol, minor_units, enabled, created_at, updated_at
+		FROM currencies
+		ORDER BY code
+	`
+	return query, nil
+}
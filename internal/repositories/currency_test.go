@@ -0,0 +1,62 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
+)
+
+func TestCurrencyRepository_List_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewCurrencyRepository(logger, db)
+
+	now := time.Now()
+	currencies := []models.CurrencyDB{
+		{Code: "EUR", NumericCode: 978, Name: "Euro", Symbol: "€", MinorUnits: 2, Enabled: true, CreatedAt: now, UpdatedAt: now},
+		{Code: "JPY", NumericCode: 392, Name: "Yen", Symbol: "¥", MinorUnits: 0, Enabled: false, CreatedAt: now, UpdatedAt: now},
+	}
+
+	rows := sqlmock.NewRows([]string{"code", "numeric_code", "name", "symbol", "minor_units", "enabled", "created_at", "updated_at"})
+	for _, c := range currencies {
+		rows.AddRow(c.Code, c.NumericCode, c.Name, c.Symbol, c.MinorUnits, c.Enabled, c.CreatedAt, c.UpdatedAt)
+	}
+
+	mock.ExpectQuery(`SELECT code, numeric_code, name, symbol, minor_units, enabled, created_at, updated_at FROM currencies ORDER BY code`).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	got, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, currencies, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCurrencyRepository_List_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewCurrencyRepository(logger, db)
+
+	mock.ExpectQuery(`SELECT code, numeric_code, name, symbol, minor_units, enabled, created_at, updated_at FROM currencies ORDER BY code`).
+		WillReturnError(sql.ErrConnDone)
+
+	ctx := context.Background()
+	got, err := repo.List(ctx)
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
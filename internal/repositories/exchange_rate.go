@@ -3,9 +3,11 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -28,10 +30,10 @@ func (r *ExchangeRateReadRepository) Get(
 	ctx context.Context,
 	fromCurrency string,
 	toCurrency string,
-) (*float64, error) {
+) (*decimal.Decimal, error) {
 
 	query, args := buildGetExchangeRateQuery(fromCurrency, toCurrency)
-	var rate float64
+	var rate decimal.Decimal
 	err := r.db.GetContext(ctx, &rate, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -44,6 +46,28 @@ func (r *ExchangeRateReadRepository) Get(
 	return &rate, nil
 }
 
+// GetAt returns the exchange rate for a currency pair that was effective at the given instant.
+func (r *ExchangeRateReadRepository) GetAt(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf time.Time,
+) (*decimal.Decimal, error) {
+
+	query, args := buildGetExchangeRateAtQuery(fromCurrency, toCurrency, asOf)
+	var rate decimal.Decimal
+	err := r.db.GetContext(ctx, &rate, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.log.Errorf("op: get exchange rate at, err: %v", err)
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
 // List returns all exchange rate records.
 func (r *ExchangeRateReadRepository) List(
 	ctx context.Context,
@@ -60,6 +84,91 @@ func (r *ExchangeRateReadRepository) List(
 	return rates, nil
 }
 
+// ListAt returns the exchange rate of every currency pair effective at the given instant
+// ordered by currency pair. Pairs without a rate at that instant are omitted.
+func (r *ExchangeRateReadRepository) ListAt(
+	ctx context.Context,
+	asOf time.Time,
+) ([]models.ExchangeRateHistoryDB, error) {
+
+	query, args := buildListExchangeRateAtQuery(asOf)
+	var rates []models.ExchangeRateHistoryDB
+	err := r.db.SelectContext(ctx, &rates, query, args...)
+	if err != nil {
+		r.log.Errorf("op: list exchange rates at, err: %v", err)
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// LastUpdatedAt returns the time of the most recent update of the exchange rates,
+// or nil if no rates are stored. A quote fetched by the rate providers counts as an update
+// even if it leaves the stored rate unchanged.
+func (r *ExchangeRateReadRepository) LastUpdatedAt(
+	ctx context.Context,
+) (*time.Time, error) {
+
+	query, args := buildLastUpdatedAtQuery()
+	var updatedAt sql.NullTime
+	err := r.db.GetContext(ctx, &updatedAt, query, args...)
+	if err != nil {
+		r.log.Errorf("op: exchange rates last updated at, err: %v", err)
+		return nil, err
+	}
+
+	if !updatedAt.Valid {
+		return nil, nil
+	}
+	return &updatedAt.Time, nil
+}
+
+// History returns the last exchange rate of a currency pair within every bucket of the given
+// width in [start, end) that contains at least one rate change, ordered by bucket.
+// Buckets are aligned to start.
+func (r *ExchangeRateReadRepository) History(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	start time.Time,
+	end time.Time,
+	interval time.Duration,
+) ([]models.ExchangeRatePointDB, error) {
+
+	query, args := buildExchangeRateHistoryQuery(fromCurrency, toCurrency, start, end, interval)
+	var points []models.ExchangeRatePointDB
+	err := r.db.SelectContext(ctx, &points, query, args...)
+	if err != nil {
+		r.log.Errorf("op: exchange rate history, err: %v", err)
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// Candles returns open, high, low and close rates of a currency pair for every bucket of the given
+// width in [start, end) that contains at least one rate change, ordered by bucket.
+// Buckets are aligned to start.
+func (r *ExchangeRateReadRepository) Candles(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	start time.Time,
+	end time.Time,
+	interval time.Duration,
+) ([]models.ExchangeRateCandleDB, error) {
+
+	query, args := buildExchangeRateCandlesQuery(fromCurrency, toCurrency, start, end, interval)
+	var candles []models.ExchangeRateCandleDB
+	err := r.db.SelectContext(ctx, &candles, query, args...)
+	if err != nil {
+		r.log.Errorf("op: exchange rate candles, err: %v", err)
+		return nil, err
+	}
+
+	return candles, nil
+}
+
 // buildGetExchangeRateQuery returns the SQL query and arguments for a single exchange rate.
 func buildGetExchangeRateQuery(fromCurrency, toCurrency string) (string, []any) {
 	query := `
@@ -71,6 +180,20 @@ func buildGetExchangeRateQuery(fromCurrency, toCurrency string) (string, []any)
 	return query, args
 }
 
+// buildGetExchangeRateAtQuery returns the SQL query and arguments for the exchange rate
+// effective at the given instant.
+func buildGetExchangeRateAtQuery(fromCurrency, toCurrency string, asOf time.Time) (string, []any) {
+	query := `
+		SELECT rate
+		FROM exchange_rate_history
+		WHERE from_currency = $1 AND to_currency = $2 AND effective_at <= $3
+		ORDER BY effective_at DESC
+		LIMIT 1
+	`
+	args := []any{fromCurrency, toCurrency, asOf}
+	return query, args
+}
+
 // buildListExchangeRateQuery returns the SQL query and empty arguments for all exchange rates.
 func buildListExchangeRateQuery() (string, []any) {
 	query := `
@@ -80,3 +203,80 @@ func buildListExchangeRateQuery() (string, []any) {
 	`
 	return query, nil
 }
+
+// buildListExchangeRateAtQuery returns the SQL query and arguments for the exchange rates
+// of all pairs effective at the given instant.
+func buildListExchangeRateAtQuery(asOf time.Time) (string, []any) {
+	query := `
+		SELECT DISTINCT ON (from_currency, to_currency) from_currency, to_currency, rate, effective_at
+		FROM exchange_rate_history
+		WHERE effective_at <= $1
+		ORDER BY from_currency, to_currency, effective_at DESC
+	`
+	args := []any{asOf}
+	return query, args
+}
+
+// buildLastUpdatedAtQuery returns the SQL query and empty arguments for the most recent rate update:
+// the latest change of a stored rate or the latest fetched quote, whichever is later.
+func buildLastUpdatedAtQuery() (string, []any) {
+	query := `
+		SELECT GREATEST(
+			(SELECT MAX(updated_at) FROM exchange_rates),
+			(SELECT MAX(quoted_at) FROM rate_quotes)
+		)
+	`
+	return query, nil
+}
+
+// buildExchangeRateHistoryQuery returns the SQL query and arguments for the last exchange rate
+// within every bucket of the rate history. date_bin requires PostgreSQL 14+.
+func buildExchangeRateHistoryQuery(
+	fromCurrency string,
+	toCurrency string,
+	start time.Time,
+	end time.Time,
+	interval time.Duration,
+) (string, []any) {
+	query := `
+		SELECT DISTINCT ON (bucket) bucket, rate
+		FROM (
+			SELECT date_bin(make_interval(secs => $3), effective_at, $4) AS bucket, rate, effective_at
+			FROM exchange_rate_history
+			WHERE from_currency = $1 AND to_currency = $2 AND effective_at >= $4 AND effective_at < $5
+		) AS h
+		ORDER BY bucket, effective_at DESC
+	`
+	args := []any{fromCurrency, toCurrency, interval.Seconds(), start, end}
+	return query, args
+}
+
+// buildExchangeRateCandlesQuery returns the SQL query and arguments for open, high, low and close
+// rates within every bucket of the rate history. date_bin requires PostgreSQL 14+.
+func buildExchangeRateCandlesQuery(
+	fromCurrency string,
+	toCurrency string,
+	start time.Time,
+	end time.Time,
+	interval time.Duration,
+) (string, []any) {
+	query := `
+		SELECT
+			bucket,
+			(array_agg(rate ORDER BY effective_at ASC))[1] AS open,
+			MAX(rate) AS high,
+			MIN(rate) AS low,
+			(array_agg(rate ORDER BY effective_at DESC))[1] AS close,
+			COUNT(*) AS changes,
+			MIN(effective_at) AS first_at
+		FROM (
+			SELECT date_bin(make_interval(secs => $3), effective_at, $4) AS bucket, rate, effective_at
+			FROM exchange_rate_history
+			WHERE from_currency = $1 AND to_currency = $2 AND effective_at >= $4 AND effective_at < $5
+		) AS h
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+	args := []any{fromCurrency, toCurrency, interval.Seconds(), start, end}
+	return query, args
+}
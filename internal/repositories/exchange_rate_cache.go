@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// exchangeRateSource is the reader wrapped by ExchangeRateCache.
+type exchangeRateSource interface {
+	Get(ctx context.Context, fromCurrency, toCurrency string) (*decimal.Decimal, error)
+	GetAt(ctx context.Context, fromCurrency, toCurrency string, asOf time.Time) (*decimal.Decimal, error)
+	List(ctx context.Context) ([]models.ExchangeRateDB, error)
+}
+
+// rateSnapshot is an immutable copy of the whole rate book.
+type rateSnapshot struct {
+	rates  []models.ExchangeRateDB
+	byPair map[string]decimal.Decimal
+}
+
+// ExchangeRateCache is a caching decorator of an exchange rate reader.
+// It keeps the whole rate book in memory as an atomically swapped snapshot
+// that is reloaded on Invalidate and periodically as a safety net.
+// Historical lookups are always delegated to the wrapped reader.
+type ExchangeRateCache struct {
+	source         exchangeRateSource
+	reloadInterval time.Duration
+	snapshot       atomic.Pointer[rateSnapshot]
+	invalidated    chan struct{}
+	log            *zap.SugaredLogger
+}
+
+// NewExchangeRateCache creates a new cache over the source reader.
+// reloadInterval is the period of the full reload; zero disables it.
+func NewExchangeRateCache(
+	log *zap.SugaredLogger,
+	source exchangeRateSource,
+	reloadInterval time.Duration,
+) *ExchangeRateCache {
+	return &ExchangeRateCache{
+		source:         source,
+		reloadInterval: reloadInterval,
+		invalidated:    make(chan struct{}, 1),
+		log:            log,
+	}
+}
+
+// Get returns the exchange rate for a currency pair from the snapshot.
+// Until the first snapshot is loaded, the call is delegated to the source.
+func (c *ExchangeRateCache) Get(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+) (*decimal.Decimal, error) {
+
+	snap := c.snapshot.Load()
+	if snap == nil {
+		return c.source.Get(ctx, fromCurrency, toCurrency)
+	}
+
+	rate, ok := snap.byPair[pairKey(fromCurrency, toCurrency)]
+	if !ok {
+		return nil, nil
+	}
+
+	return &rate, nil
+}
+
+// GetAt returns the exchange rate for a currency pair that was effective at the given instant.
+// History is not cached, so the call is delegated to the source.
+func (c *ExchangeRateCache) GetAt(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf time.Time,
+) (*decimal.Decimal, error) {
+	return c.source.GetAt(ctx, fromCurrency, toCurrency, asOf)
+}
+
+// List returns all exchange rate records from the snapshot.
+// Until the first snapshot is loaded, the call is delegated to the source.
+func (c *ExchangeRateCache) List(
+	ctx context.Context,
+) ([]models.ExchangeRateDB, error) {
+
+	snap := c.snapshot.Load()
+	if snap == nil {
+		return c.source.List(ctx)
+	}
+
+	rates := make([]models.ExchangeRateDB, len(snap.rates))
+	copy(rates, snap.rates)
+	return rates, nil
+}
+
+// Invalidate requests a reload of the snapshot. It never blocks;
+// invalidations arriving while a reload is pending are coalesced.
+func (c *ExchangeRateCache) Invalidate() {
+	select {
+	case c.invalidated <- struct{}{}:
+	default:
+	}
+}
+
+// Reload loads the whole rate book from the source and swaps the snapshot.
+// On error the previous snapshot is kept.
+func (c *ExchangeRateCache) Reload(ctx context.Context) error {
+	rates, err := c.source.List(ctx)
+	if err != nil {
+		c.log.Errorf("op: reload exchange rates cache, err: %v", err)
+		return err
+	}
+
+	byPair := make(map[string]decimal.Decimal, len(rates))
+	for _, r := range rates {
+		byPair[pairKey(r.FromCurrency, r.ToCurrency)] = r.Rate
+	}
+
+	c.snapshot.Store(&rateSnapshot{
+		rates:  rates,
+		byPair: byPair,
+	})
+	c.log.Debugf("op: reload exchange rates cache, rates: %d", len(rates))
+
+	return nil
+}
+
+// Run loads the initial snapshot and then reloads it on every invalidation
+// and every reload interval until ctx is done.
+func (c *ExchangeRateCache) Run(ctx context.Context) {
+	c.Reload(ctx)
+
+	var tick <-chan time.Time
+	if c.reloadInterval > 0 {
+		ticker := time.NewTicker(c.reloadInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.invalidated:
+			c.Reload(ctx)
+		case <-tick:
+			c.Reload(ctx)
+		}
+	}
+}
+
+// pairKey returns the map key of a currency pair.
+func pairKey(fromCurrency, toCurrency string) string {
+	return fromCurrency + ":" + toCurrency
+}
@@ -0,0 +1,159 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
+)
+
+// fakeRateSource is an in-memory reader that counts List calls.
+type fakeRateSource struct {
+	mu        sync.Mutex
+	rates     []models.ExchangeRateDB
+	listErr   error
+	listCalls int
+}
+
+func (f *fakeRateSource) set(rates ...models.ExchangeRateDB) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rates = rates
+}
+
+func (f *fakeRateSource) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.listCalls
+}
+
+func (f *fakeRateSource) Get(_ context.Context, from, to string) (*decimal.Decimal, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.rates {
+		if r.FromCurrency == from && r.ToCurrency == to {
+			rate := r.Rate
+			return &rate, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeRateSource) GetAt(ctx context.Context, from, to string, _ time.Time) (*decimal.Decimal, error) {
+	return f.Get(ctx, from, to)
+}
+
+func (f *fakeRateSource) List(_ context.Context) ([]models.ExchangeRateDB, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listCalls++
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	return append([]models.ExchangeRateDB(nil), f.rates...), nil
+}
+
+func rateRecord(from, to, rate string) models.ExchangeRateDB {
+	return models.ExchangeRateDB{FromCurrency: from, ToCurrency: to, Rate: decimal.RequireFromString(rate)}
+}
+
+func TestExchangeRateCache_DelegatesBeforeFirstLoad(t *testing.T) {
+	source := &fakeRateSource{}
+	source.set(rateRecord("USD", "RUB", "92.5"))
+	cache := repositories.NewExchangeRateCache(getLogger(t), source, 0)
+
+	got, err := cache.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(*got))
+
+	list, err := cache.List(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestExchangeRateCache_ServesSnapshot(t *testing.T) {
+	source := &fakeRateSource{}
+	source.set(rateRecord("USD", "RUB", "92.5"), rateRecord("EUR", "RUB", "100.1"))
+	cache := repositories.NewExchangeRateCache(getLogger(t), source, 0)
+
+	require.NoError(t, cache.Reload(context.Background()))
+
+	// changes in the source are not visible until the next reload
+	source.set(rateRecord("USD", "RUB", "93"))
+
+	got, err := cache.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(*got))
+
+	got, err = cache.Get(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	list, err := cache.List(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, 1, source.calls())
+
+	require.NoError(t, cache.Reload(context.Background()))
+	got, err = cache.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, decimal.RequireFromString("93").Equal(*got))
+}
+
+func TestExchangeRateCache_ReloadErrorKeepsSnapshot(t *testing.T) {
+	source := &fakeRateSource{}
+	source.set(rateRecord("USD", "RUB", "92.5"))
+	cache := repositories.NewExchangeRateCache(getLogger(t), source, 0)
+
+	require.NoError(t, cache.Reload(context.Background()))
+
+	source.listErr = errors.New("db error")
+	assert.Error(t, cache.Reload(context.Background()))
+
+	got, err := cache.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(*got))
+}
+
+func TestExchangeRateCache_RunReloadsOnInvalidate(t *testing.T) {
+	source := &fakeRateSource{}
+	source.set(rateRecord("USD", "RUB", "92.5"))
+	cache := repositories.NewExchangeRateCache(getLogger(t), source, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cache.Run(ctx)
+
+	require.Eventually(t, func() bool { return source.calls() == 1 }, time.Second, time.Millisecond)
+
+	source.set(rateRecord("USD", "RUB", "93"))
+	cache.Invalidate()
+
+	require.Eventually(t, func() bool {
+		got, err := cache.Get(ctx, "USD", "RUB")
+		return err == nil && got != nil && got.Equal(decimal.RequireFromString("93"))
+	}, time.Second, time.Millisecond)
+}
+
+func TestExchangeRateCache_RunReloadsPeriodically(t *testing.T) {
+	source := &fakeRateSource{}
+	cache := repositories.NewExchangeRateCache(getLogger(t), source, 5*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cache.Run(ctx)
+
+	require.Eventually(t, func() bool { return source.calls() >= 3 }, time.Second, time.Millisecond)
+}
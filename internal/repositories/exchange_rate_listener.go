@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// ExchangeRatesChangedChannel is the Postgres NOTIFY channel
+// signalled by the trigger on the exchange_rates table.
+const ExchangeRatesChangedChannel = "exchange_rates_changed"
+
+// Reconnect backoff bounds of ExchangeRateListener.
+const (
+	listenerMinBackoff = time.Second
+	listenerMaxBackoff = 30 * time.Second
+)
+
+// ExchangeRateListener listens for exchange rate change notifications from Postgres.
+type ExchangeRateListener struct {
+	dsn string
+	log *zap.SugaredLogger
+}
+
+// NewExchangeRateListener creates a new listener for the database at dsn.
+func NewExchangeRateListener(log *zap.SugaredLogger, dsn string) *ExchangeRateListener {
+	return &ExchangeRateListener{
+		dsn: dsn,
+		log: log,
+	}
+}
+
+// Listen calls onChange for every notification on ExchangeRatesChangedChannel until ctx is done.
+// A dedicated connection is used and re-established with backoff when it is lost;
+// onChange is also called after every (re)connect since notifications may have been missed.
+func (l *ExchangeRateListener) Listen(ctx context.Context, onChange func()) {
+	backoff := listenerMinBackoff
+	for {
+		err := l.listen(ctx, onChange, func() { backoff = listenerMinBackoff })
+		if ctx.Err() != nil {
+			return
+		}
+		l.log.Errorf("op: listen exchange rates, err: %v, reconnecting in %s", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, listenerMaxBackoff)
+	}
+}
+
+// listen runs a single LISTEN session until the connection fails or ctx is done.
+func (l *ExchangeRateListener) listen(ctx context.Context, onChange func(), onConnected func()) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+ExchangeRatesChangedChannel); err != nil {
+		return err
+	}
+	onConnected()
+	l.log.Infof("Listening for exchange rate changes on channel %s", ExchangeRatesChangedChannel)
+	onChange()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		l.log.Debugf("op: listen exchange rates, notification: %s", n.Payload)
+		onChange()
+	}
+}
@@ -0,0 +1,332 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Redis key layout:
+//
+//	exchange_rates:pairs          SET of "FROM:TO" pairs
+//	exchange_rate:FROM:TO         HASH with the fields of models.ExchangeRateDB
+//	exchange_rates:version        INTEGER incremented by ReplaceAll on every write
+const (
+	redisPairsKey   = "exchange_rates:pairs"
+	redisVersionKey = "exchange_rates:version"
+	redisRateKey    = "exchange_rate:%s:%s"
+)
+
+// redisListAttempts limits how many times List re-reads the rate book
+// when the version changes while it is being read.
+const redisListAttempts = 3
+
+// redisReplaceAttempts limits how many times ReplaceAll retries its transaction
+// when the stored pairs change between the read and the write.
+const redisReplaceAttempts = 3
+
+// errRedisVersionChanged signals that the rate book was modified during List.
+var errRedisVersionChanged = errors.New("exchange rates version changed during read")
+
+// ExchangeRateRedisRepository reads current currency exchange rates from Redis
+// and replaces them with the rate book copied from PostgreSQL.
+// Redis keeps no rate history; see ExchangeRateRedisReader.
+type ExchangeRateRedisRepository struct {
+	rdb *redis.Client
+	log *zap.SugaredLogger
+}
+
+// NewExchangeRateRedisRepository creates a new Redis repository with a logger.
+func NewExchangeRateRedisRepository(log *zap.SugaredLogger, rdb *redis.Client) *ExchangeRateRedisRepository {
+	return &ExchangeRateRedisRepository{
+		rdb: rdb,
+		log: log,
+	}
+}
+
+// Get returns the exchange rate for a currency pair.
+func (r *ExchangeRateRedisRepository) Get(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+) (*decimal.Decimal, error) {
+
+	rate, err := r.getRecord(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		r.log.Errorf("op: get exchange rate, err: %v", err)
+		return nil, err
+	}
+	if rate == nil {
+		return nil, nil
+	}
+
+	return &rate.Rate, nil
+}
+
+// List returns all exchange rate records ordered by creation time, newest first,
+// like ExchangeRateReadRepository.List. The read is retried if the version key changes while the records are being read,
+// so the result is a consistent snapshot of the rate book.
+func (r *ExchangeRateRedisRepository) List(
+	ctx context.Context,
+) ([]models.ExchangeRateDB, error) {
+
+	var err error
+	for attempt := 0; attempt < redisListAttempts; attempt++ {
+		var rates []models.ExchangeRateDB
+		rates, err = r.list(ctx)
+		if err == nil {
+			return rates, nil
+		}
+		if !errors.Is(err, errRedisVersionChanged) {
+			break
+		}
+	}
+
+	r.log.Errorf("op: list exchange rates, err: %v", err)
+	return nil, err
+}
+
+// list reads all exchange rate records and verifies that the version key
+// did not change during the read.
+func (r *ExchangeRateRedisRepository) list(ctx context.Context) ([]models.ExchangeRateDB, error) {
+	version, err := r.version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := r.rdb.SMembers(ctx, redisPairsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	// Pairs are sorted so that rates created at the same instant keep a stable order.
+	sort.Strings(pairs)
+
+	pipe := r.rdb.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, 0, len(pairs))
+	for _, pair := range pairs {
+		from, to, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("malformed exchange rate pair: %q", pair)
+		}
+		cmds = append(cmds, pipe.HGetAll(ctx, redisRateKeyFor(from, to)))
+	}
+	if len(cmds) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	rates := make([]models.ExchangeRateDB, 0, len(cmds))
+	for _, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			continue
+		}
+		rate, err := parseRedisRate(fields)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, *rate)
+	}
+
+	current, err := r.version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current != version {
+		return nil, errRedisVersionChanged
+	}
+
+	sort.SliceStable(rates, func(i, j int) bool {
+		return rates[i].CreatedAt.After(rates[j].CreatedAt)
+	})
+	return rates, nil
+}
+
+// ReplaceAll replaces the stored rate book with the given rates and increments the version key
+// in a single MULTI/EXEC transaction, so readers never observe a partially written book.
+// The stored pairs are read under WATCH, and the transaction is retried if they change before it runs.
+func (r *ExchangeRateRedisRepository) ReplaceAll(
+	ctx context.Context,
+	rates []models.ExchangeRateDB,
+) error {
+
+	pairs := make(map[string]struct{}, len(rates))
+	for _, rate := range rates {
+		pairs[pairKey(rate.FromCurrency, rate.ToCurrency)] = struct{}{}
+	}
+
+	replace := func(tx *redis.Tx) error {
+		stored, err := tx.SMembers(ctx, redisPairsKey).Result()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, pair := range stored {
+				if _, ok := pairs[pair]; ok {
+					continue
+				}
+				if from, to, ok := strings.Cut(pair, ":"); ok {
+					pipe.Del(ctx, redisRateKeyFor(from, to))
+				}
+			}
+			pipe.Del(ctx, redisPairsKey)
+			for _, rate := range rates {
+				pipe.HSet(ctx, redisRateKeyFor(rate.FromCurrency, rate.ToCurrency), redisRateFields(rate))
+				pipe.SAdd(ctx, redisPairsKey, pairKey(rate.FromCurrency, rate.ToCurrency))
+			}
+			pipe.Incr(ctx, redisVersionKey)
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < redisReplaceAttempts; attempt++ {
+		err = r.rdb.Watch(ctx, replace, redisPairsKey)
+		if !errors.Is(err, redis.TxFailedErr) {
+			break
+		}
+	}
+	if err != nil {
+		r.log.Errorf("op: replace exchange rates, err: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// exchangeRateHistorySource is the reader of historical rates used by ExchangeRateRedisReader.
+type exchangeRateHistorySource interface {
+	GetAt(ctx context.Context, fromCurrency, toCurrency string, asOf time.Time) (*decimal.Decimal, error)
+}
+
+// ExchangeRateRedisReader reads current exchange rates from Redis and delegates historical
+// lookups to history, since the rate history is kept only in PostgreSQL.
+type ExchangeRateRedisReader struct {
+	current *ExchangeRateRedisRepository
+	history exchangeRateHistorySource
+}
+
+// NewExchangeRateRedisReader creates a reader of current rates from Redis and historical rates from history.
+func NewExchangeRateRedisReader(
+	current *ExchangeRateRedisRepository,
+	history exchangeRateHistorySource,
+) *ExchangeRateRedisReader {
+	return &ExchangeRateRedisReader{
+		current: current,
+		history: history,
+	}
+}
+
+// Get returns the current exchange rate for a currency pair from Redis.
+func (r *ExchangeRateRedisReader) Get(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+) (*decimal.Decimal, error) {
+	return r.current.Get(ctx, fromCurrency, toCurrency)
+}
+
+// GetAt returns the exchange rate for a currency pair that was effective at the given instant
+// from the rate history.
+func (r *ExchangeRateRedisReader) GetAt(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf time.Time,
+) (*decimal.Decimal, error) {
+	return r.history.GetAt(ctx, fromCurrency, toCurrency, asOf)
+}
+
+// List returns all current exchange rate records from Redis.
+func (r *ExchangeRateRedisReader) List(
+	ctx context.Context,
+) ([]models.ExchangeRateDB, error) {
+	return r.current.List(ctx)
+}
+
+// getRecord reads the full exchange rate record for a currency pair.
+func (r *ExchangeRateRedisRepository) getRecord(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+) (*models.ExchangeRateDB, error) {
+
+	fields, err := r.rdb.HGetAll(ctx, redisRateKeyFor(fromCurrency, toCurrency)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return parseRedisRate(fields)
+}
+
+// version returns the current value of the version key, 0 if it is not set.
+func (r *ExchangeRateRedisRepository) version(ctx context.Context) (int64, error) {
+	v, err := r.rdb.Get(ctx, redisVersionKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return v, err
+}
+
+// redisRateKeyFor returns the hash key of a currency pair.
+func redisRateKeyFor(fromCurrency, toCurrency string) string {
+	return fmt.Sprintf(redisRateKey, fromCurrency, toCurrency)
+}
+
+// redisRateFields converts an exchange rate record to the fields of its hash.
+func redisRateFields(rate models.ExchangeRateDB) map[string]any {
+	return map[string]any{
+		"exchange_rate_id": rate.ExchangeRateID.String(),
+		"from_currency":    rate.FromCurrency,
+		"to_currency":      rate.ToCurrency,
+		"rate":             rate.Rate.String(),
+		"created_at":       rate.CreatedAt.UTC().Format(time.RFC3339Nano),
+		"updated_at":       rate.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// parseRedisRate converts the fields of a rate hash to an exchange rate record.
+func parseRedisRate(fields map[string]string) (*models.ExchangeRateDB, error) {
+	rate, err := decimal.NewFromString(fields["rate"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate %q: %w", fields["rate"], err)
+	}
+
+	record := &models.ExchangeRateDB{
+		FromCurrency: fields["from_currency"],
+		ToCurrency:   fields["to_currency"],
+		Rate:         rate,
+	}
+	if v := fields["exchange_rate_id"]; v != "" {
+		if record.ExchangeRateID, err = uuid.Parse(v); err != nil {
+			return nil, fmt.Errorf("invalid exchange_rate_id %q: %w", v, err)
+		}
+	}
+	if v := fields["created_at"]; v != "" {
+		if record.CreatedAt, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return nil, fmt.Errorf("invalid created_at %q: %w", v, err)
+		}
+	}
+	if v := fields["updated_at"]; v != "" {
+		if record.UpdatedAt, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return nil, fmt.Errorf("invalid updated_at %q: %w", v, err)
+		}
+	}
+
+	return record, nil
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"go.uber.org/zap"
+)
+
+// exchangeRateLister reads the whole rate book.
+type exchangeRateLister interface {
+	List(ctx context.Context) ([]models.ExchangeRateDB, error)
+}
+
+// ExchangeRateRedisSync keeps Redis a copy of the rate book stored in PostgreSQL.
+// Every writer (admin service, providers, import) writes to PostgreSQL only;
+// the sync copies the whole book to Redis on every Notify and sync interval.
+type ExchangeRateRedisSync struct {
+	source       exchangeRateLister
+	target       *ExchangeRateRedisRepository
+	syncInterval time.Duration
+	onSynced     func()
+	notified     chan struct{}
+	log          *zap.SugaredLogger
+}
+
+// NewExchangeRateRedisSync creates a new sync from source to target.
+// syncInterval is the period of the sync as a safety net for missed
+// notifications; zero disables it. onSynced, if not nil, is called after
+// every successful sync so that readers of Redis can pick up the changes.
+func NewExchangeRateRedisSync(
+	log *zap.SugaredLogger,
+	source exchangeRateLister,
+	target *ExchangeRateRedisRepository,
+	syncInterval time.Duration,
+	onSynced func(),
+) *ExchangeRateRedisSync {
+	return &ExchangeRateRedisSync{
+		source:       source,
+		target:       target,
+		syncInterval: syncInterval,
+		onSynced:     onSynced,
+		notified:     make(chan struct{}, 1),
+		log:          log,
+	}
+}
+
+// Notify requests a sync. It never blocks;
+// notifications arriving while a sync is pending are coalesced.
+func (s *ExchangeRateRedisSync) Notify() {
+	select {
+	case s.notified <- struct{}{}:
+	default:
+	}
+}
+
+// Sync copies the rate book from the source to Redis.
+// On error Redis keeps the previous copy.
+func (s *ExchangeRateRedisSync) Sync(ctx context.Context) error {
+	rates, err := s.source.List(ctx)
+	if err != nil {
+		s.log.Errorf("op: sync exchange rates to redis, err: %v", err)
+		return err
+	}
+
+	if err := s.target.ReplaceAll(ctx, rates); err != nil {
+		s.log.Errorf("op: sync exchange rates to redis, err: %v", err)
+		return err
+	}
+	s.log.Debugf("op: sync exchange rates to redis, rates: %d", len(rates))
+
+	if s.onSynced != nil {
+		s.onSynced()
+	}
+	return nil
+}
+
+// Run copies the rate book right away and then on every notification
+// and every sync interval until ctx is done.
+func (s *ExchangeRateRedisSync) Run(ctx context.Context) {
+	s.Sync(ctx)
+
+	var tick <-chan time.Time
+	if s.syncInterval > 0 {
+		ticker := time.NewTicker(s.syncInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.notified:
+			s.Sync(ctx)
+		case <-tick:
+			s.Sync(ctx)
+		}
+	}
+}
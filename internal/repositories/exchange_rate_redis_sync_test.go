@@ -0,0 +1,83 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
+)
+
+func TestExchangeRateRedisSync_Sync(t *testing.T) {
+	_, rdb := getMiniRedis(t)
+	target := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+	source := &fakeRateSource{}
+	source.set(rateRecord("USD", "RUB", "92.5"), rateRecord("EUR", "RUB", "100.1"))
+
+	var synced atomic.Int32
+	redisSync := repositories.NewExchangeRateRedisSync(getLogger(t), source, target, 0, func() { synced.Add(1) })
+
+	require.NoError(t, redisSync.Sync(context.Background()))
+	assert.Equal(t, int32(1), synced.Load())
+
+	got, err := target.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(*got))
+
+	// a pair deleted in the source disappears from Redis
+	source.set(rateRecord("USD", "RUB", "93"))
+	require.NoError(t, redisSync.Sync(context.Background()))
+
+	list, err := target.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.True(t, decimal.RequireFromString("93").Equal(list[0].Rate))
+}
+
+func TestExchangeRateRedisSync_SourceErrorKeepsCopy(t *testing.T) {
+	_, rdb := getMiniRedis(t)
+	target := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+	source := &fakeRateSource{}
+	source.set(rateRecord("USD", "RUB", "92.5"))
+
+	var synced atomic.Int32
+	redisSync := repositories.NewExchangeRateRedisSync(getLogger(t), source, target, 0, func() { synced.Add(1) })
+	require.NoError(t, redisSync.Sync(context.Background()))
+
+	source.listErr = errors.New("db error")
+	assert.Error(t, redisSync.Sync(context.Background()))
+	assert.Equal(t, int32(1), synced.Load(), "not announced on error")
+
+	got, err := target.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestExchangeRateRedisSync_RunSyncsOnNotify(t *testing.T) {
+	_, rdb := getMiniRedis(t)
+	target := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+	source := &fakeRateSource{}
+	source.set(rateRecord("USD", "RUB", "92.5"))
+	redisSync := repositories.NewExchangeRateRedisSync(getLogger(t), source, target, 0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go redisSync.Run(ctx)
+
+	require.Eventually(t, func() bool { return source.calls() == 1 }, time.Second, time.Millisecond)
+
+	source.set(rateRecord("USD", "RUB", "93"))
+	redisSync.Notify()
+
+	require.Eventually(t, func() bool {
+		got, err := target.Get(ctx, "USD", "RUB")
+		return err == nil && got != nil && got.Equal(decimal.RequireFromString("93"))
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,259 @@
+package repositories_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
+)
+
+// helper to create a Redis client backed by miniredis
+func getMiniRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return mr, rdb
+}
+
+// helper to store a rate hash the way writers do
+func seedRedisRate(mr *miniredis.Miniredis, from, to, rate string, updatedAt time.Time) uuid.UUID {
+	id := uuid.New()
+	mr.SAdd("exchange_rates:pairs", from+":"+to)
+	mr.HSet("exchange_rate:"+from+":"+to,
+		"exchange_rate_id", id.String(),
+		"from_currency", from,
+		"to_currency", to,
+		"rate", rate,
+		"created_at", updatedAt.Format(time.RFC3339Nano),
+		"updated_at", updatedAt.Format(time.RFC3339Nano),
+	)
+	mr.Incr("exchange_rates:version", 1)
+	return id
+}
+
+func TestExchangeRateRedisRepository_Get_Success(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	seedRedisRate(mr, "USD", "RUB", "92.123456", time.Now())
+
+	got, err := repo.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, decimal.RequireFromString("92.123456").Equal(*got))
+}
+
+func TestExchangeRateRedisRepository_Get_NotFound(t *testing.T) {
+	_, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	got, err := repo.Get(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExchangeRateRedisRepository_Get_MalformedRate(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	mr.HSet("exchange_rate:USD:RUB", "from_currency", "USD", "to_currency", "RUB", "rate", "abc")
+
+	got, err := repo.Get(context.Background(), "USD", "RUB")
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExchangeRateRedisRepository_Get_ConnectionError(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	mr.Close()
+
+	got, err := repo.Get(context.Background(), "USD", "RUB")
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExchangeRateRedisReader(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	reader := repositories.NewExchangeRateRedisReader(
+		repositories.NewExchangeRateRedisRepository(logger, rdb),
+		repositories.NewExchangeRateReadRepository(logger, db),
+	)
+
+	updatedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	seedRedisRate(mr, "USD", "RUB", "92.5", updatedAt)
+
+	// Current rates are read from Redis.
+	got, err := reader.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(*got))
+
+	rates, err := reader.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "USD", rates[0].FromCurrency)
+
+	// As-of lookups before the last update are answered from the PostgreSQL history.
+	asOf := updatedAt.Add(-time.Hour)
+	mock.ExpectQuery(`SELECT rate FROM exchange_rate_history WHERE from_currency = \$1 AND to_currency = \$2 AND effective_at <= \$3 ORDER BY effective_at DESC LIMIT 1`).
+		WithArgs("USD", "RUB", asOf).
+		WillReturnRows(sqlmock.NewRows([]string{"rate"}).AddRow("90.1"))
+
+	got, err = reader.GetAt(context.Background(), "USD", "RUB", asOf)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, decimal.RequireFromString("90.1").Equal(*got))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateRedisRepository_List_Success(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	now := time.Now().UTC()
+	usdRubID := seedRedisRate(mr, "USD", "RUB", "92.5", now)
+	eurRubID := seedRedisRate(mr, "EUR", "RUB", "100.1", now)
+	// pair registered without a hash is skipped
+	mr.SAdd("exchange_rates:pairs", "EUR:USD")
+
+	got, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, eurRubID, got[0].ExchangeRateID)
+	assert.Equal(t, usdRubID, got[1].ExchangeRateID)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(got[1].Rate))
+	assert.True(t, now.Equal(got[1].UpdatedAt))
+}
+
+func TestExchangeRateRedisRepository_List_Empty(t *testing.T) {
+	_, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	got, err := repo.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestExchangeRateRedisRepository_List_MalformedPair(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	mr.SAdd("exchange_rates:pairs", "USDRUB")
+
+	got, err := repo.List(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExchangeRateRedisRepository_List_OrderedByCreatedAt(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	now := time.Now().UTC()
+	seedRedisRate(mr, "EUR", "RUB", "100.1", now.Add(-time.Hour))
+	seedRedisRate(mr, "USD", "RUB", "92.5", now)
+
+	got, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "USD", got[0].FromCurrency, "newest first")
+	assert.Equal(t, "EUR", got[1].FromCurrency)
+}
+
+func TestExchangeRateRedisRepository_ReplaceAll(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	updatedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	seedRedisRate(mr, "EUR", "RUB", "100.1", updatedAt)
+	id := uuid.New()
+
+	err := repo.ReplaceAll(context.Background(), []models.ExchangeRateDB{{
+		ExchangeRateID: id,
+		FromCurrency:   "USD",
+		ToCurrency:     "RUB",
+		Rate:           decimal.RequireFromString("92.123456"),
+		CreatedAt:      updatedAt,
+		UpdatedAt:      updatedAt,
+	}})
+	require.NoError(t, err)
+
+	got, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, id, got[0].ExchangeRateID)
+	assert.True(t, decimal.RequireFromString("92.123456").Equal(got[0].Rate))
+	assert.True(t, updatedAt.Equal(got[0].UpdatedAt))
+
+	assert.False(t, mr.Exists("exchange_rate:EUR:RUB"), "hash of a removed pair is deleted")
+	version, err := mr.Get("exchange_rates:version")
+	require.NoError(t, err)
+	assert.Equal(t, "2", version)
+}
+
+func TestExchangeRateRedisRepository_ReplaceAll_Concurrent(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	updatedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	currencies := []string{"USD", "EUR", "GBP", "CNY", "JPY", "CHF", "TRY", "KZT"}
+
+	var wg sync.WaitGroup
+	for _, from := range currencies {
+		wg.Add(1)
+		go func(from string) {
+			defer wg.Done()
+			err := repo.ReplaceAll(context.Background(), []models.ExchangeRateDB{{
+				ExchangeRateID: uuid.New(),
+				FromCurrency:   from,
+				ToCurrency:     "RUB",
+				Rate:           decimal.RequireFromString("1.5"),
+				CreatedAt:      updatedAt,
+				UpdatedAt:      updatedAt,
+			}})
+			if err != nil {
+				assert.ErrorIs(t, err, redis.TxFailedErr)
+			}
+		}(from)
+	}
+	wg.Wait()
+
+	// Whatever write won, no hash of a pair missing from the pairs set is left behind.
+	pairs, err := mr.Members("exchange_rates:pairs")
+	require.NoError(t, err)
+	require.Len(t, pairs, 1)
+	for _, key := range mr.Keys() {
+		if strings.HasPrefix(key, "exchange_rate:") {
+			assert.Equal(t, "exchange_rate:"+pairs[0], key)
+		}
+	}
+}
+
+func TestExchangeRateRedisRepository_ReplaceAll_ConnectionError(t *testing.T) {
+	mr, rdb := getMiniRedis(t)
+	repo := repositories.NewExchangeRateRedisRepository(getLogger(t), rdb)
+
+	mr.Close()
+
+	err := repo.ReplaceAll(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -17,6 +18,8 @@ import (
 	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
 )
 
+const lastUpdatedAtQuery = `SELECT GREATEST\( \(SELECT MAX\(updated_at\) FROM exchange_rates\), \(SELECT MAX\(quoted_at\) FROM rate_quotes\) \)`
+
 // helper to create logger
 func getLogger(t *testing.T) *zap.SugaredLogger {
 	logger, err := zap.NewDevelopment()
@@ -41,17 +44,17 @@ func TestExchangeRateReadRepository_Get_Success(t *testing.T) {
 
 	from := "USD"
 	to := "EUR"
-	rate := 1.23
+	rate := decimal.RequireFromString("1.23")
 
 	mock.ExpectQuery(`SELECT rate FROM exchange_rates WHERE from_currency = \$1 AND to_currency = \$2`).
 		WithArgs(from, to).
-		WillReturnRows(sqlmock.NewRows([]string{"rate"}).AddRow(rate))
+		WillReturnRows(sqlmock.NewRows([]string{"rate"}).AddRow(rate.String()))
 
 	ctx := context.Background()
 	got, err := repo.Get(ctx, from, to)
 	require.NoError(t, err)
 	require.NotNil(t, got)
-	assert.Equal(t, rate, *got)
+	assert.True(t, rate.Equal(*got))
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -100,6 +103,77 @@ func TestExchangeRateReadRepository_Get_Error(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestExchangeRateReadRepository_GetAt_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	from := "USD"
+	to := "RUB"
+	asOf := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	rate := decimal.RequireFromString("101.5")
+
+	mock.ExpectQuery(`SELECT rate FROM exchange_rate_history WHERE from_currency = \$1 AND to_currency = \$2 AND effective_at <= \$3 ORDER BY effective_at DESC LIMIT 1`).
+		WithArgs(from, to, asOf).
+		WillReturnRows(sqlmock.NewRows([]string{"rate"}).AddRow(rate.String()))
+
+	ctx := context.Background()
+	got, err := repo.GetAt(ctx, from, to, asOf)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, rate.Equal(*got))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_GetAt_NotFound(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	from := "USD"
+	to := "RUB"
+	asOf := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT rate FROM exchange_rate_history WHERE from_currency = \$1 AND to_currency = \$2 AND effective_at <= \$3 ORDER BY effective_at DESC LIMIT 1`).
+		WithArgs(from, to, asOf).
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := context.Background()
+	got, err := repo.GetAt(ctx, from, to, asOf)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_GetAt_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	from := "USD"
+	to := "RUB"
+	asOf := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT rate FROM exchange_rate_history WHERE from_currency = \$1 AND to_currency = \$2 AND effective_at <= \$3 ORDER BY effective_at DESC LIMIT 1`).
+		WithArgs(from, to, asOf).
+		WillReturnError(sql.ErrConnDone)
+
+	ctx := context.Background()
+	got, err := repo.GetAt(ctx, from, to, asOf)
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestExchangeRateReadRepository_List_Success(t *testing.T) {
 	db, mock, closeFn := getMockDB(t)
 	defer closeFn()
@@ -108,13 +182,13 @@ func TestExchangeRateReadRepository_List_Success(t *testing.T) {
 	repo := repositories.NewExchangeRateReadRepository(logger, db)
 
 	rates := []models.ExchangeRateDB{
-		{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "EUR", Rate: 1.23, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-		{ExchangeRateID: uuid.New(), FromCurrency: "EUR", ToCurrency: "USD", Rate: 0.81, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "EUR", Rate: decimal.RequireFromString("1.23"), CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ExchangeRateID: uuid.New(), FromCurrency: "EUR", ToCurrency: "USD", Rate: decimal.RequireFromString("0.81"), CreatedAt: time.Now(), UpdatedAt: time.Now()},
 	}
 
 	rows := sqlmock.NewRows([]string{"exchange_rate_id", "from_currency", "to_currency", "rate", "created_at", "updated_at"})
 	for _, r := range rates {
-		rows.AddRow(r.ExchangeRateID.String(), r.FromCurrency, r.ToCurrency, r.Rate, r.CreatedAt, r.UpdatedAt)
+		rows.AddRow(r.ExchangeRateID.String(), r.FromCurrency, r.ToCurrency, r.Rate.String(), r.CreatedAt, r.UpdatedAt)
 	}
 
 	mock.ExpectQuery(`SELECT exchange_rate_id, from_currency, to_currency, rate, created_at, updated_at FROM exchange_rates ORDER BY created_at DESC`).
@@ -146,3 +220,201 @@ func TestExchangeRateReadRepository_List_Error(t *testing.T) {
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestExchangeRateReadRepository_ListAt_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	asOf := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"from_currency", "to_currency", "rate", "effective_at"}).
+		AddRow("EUR", "RUB", "100.1", asOf.Add(-time.Hour)).
+		AddRow("USD", "RUB", "92.5", asOf.Add(-24*time.Hour))
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(from_currency, to_currency\) from_currency, to_currency, rate, effective_at FROM exchange_rate_history WHERE effective_at <= \$1 ORDER BY from_currency, to_currency, effective_at DESC`).
+		WithArgs(asOf).
+		WillReturnRows(rows)
+
+	got, err := repo.ListAt(context.Background(), asOf)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "EUR", got[0].FromCurrency)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(got[1].Rate))
+	assert.True(t, asOf.Add(-24*time.Hour).Equal(got[1].EffectiveAt))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_ListAt_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(from_currency, to_currency\)`).
+		WillReturnError(sql.ErrConnDone)
+
+	got, err := repo.ListAt(context.Background(), time.Now())
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_LastUpdatedAt_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	updatedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(lastUpdatedAtQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(updatedAt))
+
+	got, err := repo.LastUpdatedAt(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, updatedAt.Equal(*got))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_LastUpdatedAt_NoRates(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	mock.ExpectQuery(lastUpdatedAtQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+
+	got, err := repo.LastUpdatedAt(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_LastUpdatedAt_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	mock.ExpectQuery(lastUpdatedAtQuery).
+		WillReturnError(sql.ErrConnDone)
+
+	got, err := repo.LastUpdatedAt(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_History_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	from := "USD"
+	to := "RUB"
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	rows := sqlmock.NewRows([]string{"bucket", "rate"}).
+		AddRow(start, "92.5").
+		AddRow(start.Add(2*time.Hour), "93.1")
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(bucket\) bucket, rate FROM .*date_bin\(make_interval\(secs => \$3\), effective_at, \$4\).* FROM exchange_rate_history WHERE from_currency = \$1 AND to_currency = \$2 AND effective_at >= \$4 AND effective_at < \$5 .*ORDER BY bucket, effective_at DESC`).
+		WithArgs(from, to, float64(3600), start, end).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	got, err := repo.History(ctx, from, to, start, end, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.True(t, start.Equal(got[0].Bucket))
+	assert.True(t, decimal.RequireFromString("93.1").Equal(got[1].Rate))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_History_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(bucket\) bucket, rate FROM`).
+		WillReturnError(sql.ErrConnDone)
+
+	ctx := context.Background()
+	got, err := repo.History(ctx, "USD", "RUB", start, start.Add(time.Hour), time.Minute)
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_Candles_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	from := "USD"
+	to := "RUB"
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	rows := sqlmock.NewRows([]string{"bucket", "open", "high", "low", "close", "changes", "first_at"}).
+		AddRow(start, "92.5", "93.4", "91.8", "92.9", 4, start.Add(time.Hour))
+
+	mock.ExpectQuery(`SELECT bucket, \(array_agg\(rate ORDER BY effective_at ASC\)\)\[1\] AS open, MAX\(rate\) AS high, MIN\(rate\) AS low, \(array_agg\(rate ORDER BY effective_at DESC\)\)\[1\] AS close, COUNT\(\*\) AS changes, MIN\(effective_at\) AS first_at FROM .* FROM exchange_rate_history WHERE from_currency = \$1 AND to_currency = \$2 AND effective_at >= \$4 AND effective_at < \$5 .*GROUP BY bucket ORDER BY bucket`).
+		WithArgs(from, to, float64(86400), start, end).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	got, err := repo.Candles(ctx, from, to, start, end, 24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.True(t, decimal.RequireFromString("92.5").Equal(got[0].Open))
+	assert.True(t, decimal.RequireFromString("93.4").Equal(got[0].High))
+	assert.True(t, decimal.RequireFromString("91.8").Equal(got[0].Low))
+	assert.True(t, decimal.RequireFromString("92.9").Equal(got[0].Close))
+	assert.Equal(t, int64(4), got[0].Changes)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateReadRepository_Candles_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateReadRepository(logger, db)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT bucket, \(array_agg`).
+		WillReturnError(sql.ErrConnDone)
+
+	ctx := context.Background()
+	got, err := repo.Candles(ctx, "USD", "RUB", start, start.Add(time.Hour), time.Minute)
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// ExchangeRateWriteRepository writes currency exchange rates to the DB.
+type ExchangeRateWriteRepository struct {
+	db  *sqlx.DB
+	log *zap.SugaredLogger
+}
+
+// NewExchangeRateWriteRepository creates a new write repository with a logger.
+func NewExchangeRateWriteRepository(log *zap.SugaredLogger, db *sqlx.DB) *ExchangeRateWriteRepository {
+	return &ExchangeRateWriteRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Save creates the exchange rate for a currency pair or updates the existing one
+// and bumps its updated_at. Saving the stored rate again leaves the record unchanged.
+// It returns the stored record.
+func (r *ExchangeRateWriteRepository) Save(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	rate decimal.Decimal,
+) (*models.ExchangeRateDB, error) {
+
+	saved, err := saveExchangeRate(ctx, r.db, fromCurrency, toCurrency, rate)
+	if err != nil {
+		r.log.Errorf("op: save exchange rate, err: %v", err)
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// SaveAll creates or updates several exchange rates in a single transaction.
+// Either all rates are stored or none of them.
+func (r *ExchangeRateWriteRepository) SaveAll(
+	ctx context.Context,
+	rates []models.ExchangeRateDB,
+) ([]models.ExchangeRateDB, error) {
+
+	saved, err := r.saveAll(ctx, rates, nil)
+	if err != nil {
+		r.log.Errorf("op: save exchange rates, err: %v", err)
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// SaveAllWithQuotes creates or updates several exchange rates and records the provider quotes
+// they were computed from in a single transaction.
+func (r *ExchangeRateWriteRepository) SaveAllWithQuotes(
+	ctx context.Context,
+	rates []models.ExchangeRateDB,
+	quotes []models.RateQuoteDB,
+) ([]models.ExchangeRateDB, error) {
+
+	saved, err := r.saveAll(ctx, rates, quotes)
+	if err != nil {
+		r.log.Errorf("op: save exchange rates with quotes, err: %v", err)
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// saveAll upserts the rates and inserts the quotes in a single transaction.
+func (r *ExchangeRateWriteRepository) saveAll(
+	ctx context.Context,
+	rates []models.ExchangeRateDB,
+	quotes []models.RateQuoteDB,
+) ([]models.ExchangeRateDB, error) {
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	saved := make([]models.ExchangeRateDB, 0, len(rates))
+	for _, rate := range rates {
+		row, err := saveExchangeRate(ctx, tx, rate.FromCurrency, rate.ToCurrency, rate.Rate)
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, *row)
+	}
+
+	for _, quote := range quotes {
+		query, args := buildInsertRateQuoteQuery(quote)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// saveExchangeRate upserts the rate of a currency pair and returns the stored record.
+// The upsert returns no row when the rate is unchanged, so the record is then read as is.
+func saveExchangeRate(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	fromCurrency string,
+	toCurrency string,
+	rate decimal.Decimal,
+) (*models.ExchangeRateDB, error) {
+
+	query, args := buildSaveExchangeRateQuery(fromCurrency, toCurrency, rate)
+	var saved models.ExchangeRateDB
+	err := sqlx.GetContext(ctx, q, &saved, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		query, args = buildGetExchangeRateRecordQuery(fromCurrency, toCurrency)
+		err = sqlx.GetContext(ctx, q, &saved, query, args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Import loads exchange rates effective at past instants in a single transaction.
+// Rates are applied in order of effective_at: a rate newer than the stored one of its pair
+// replaces it, an older one is only added to the pair history unless the pair already has
+// a rate at that instant, so that importing the same rates again changes nothing.
+// It returns the number of rates that replaced the stored rate of their pair.
+func (r *ExchangeRateWriteRepository) Import(
+	ctx context.Context,
+	rates []models.ExchangeRateHistoryDB,
+) (int, error) {
+
+	sorted := make([]models.ExchangeRateHistoryDB, len(rates))
+	copy(sorted, rates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EffectiveAt.Before(sorted[j].EffectiveAt)
+	})
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		r.log.Errorf("op: import exchange rates, err: %v", err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	current := 0
+	for _, rate := range sorted {
+		query, args := buildImportExchangeRateQuery(rate)
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			r.log.Errorf("op: import exchange rates, err: %v", err)
+			return 0, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			r.log.Errorf("op: import exchange rates, err: %v", err)
+			return 0, err
+		}
+		if affected > 0 {
+			// The history trigger has recorded the rate.
+			current++
+			continue
+		}
+
+		query, args = buildInsertExchangeRateHistoryQuery(rate)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			r.log.Errorf("op: import exchange rates, err: %v", err)
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Errorf("op: import exchange rates, err: %v", err)
+		return 0, err
+	}
+
+	return current, nil
+}
+
+// Delete removes the exchange rate for a currency pair.
+// It reports whether the pair existed.
+func (r *ExchangeRateWriteRepository) Delete(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+) (bool, error) {
+
+	query, args := buildDeleteExchangeRateQuery(fromCurrency, toCurrency)
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.log.Errorf("op: delete exchange rate, err: %v", err)
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		r.log.Errorf("op: delete exchange rate, err: %v", err)
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// buildSaveExchangeRateQuery returns the SQL upsert query and arguments for a single exchange rate.
+// A stored rate equal to the saved one is not updated, so that it is not recorded in the history again.
+func buildSaveExchangeRateQuery(fromCurrency, toCurrency string, rate decimal.Decimal) (string, []any) {
+	query := `
+		INSERT INTO exchange_rates (from_currency, to_currency, rate)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (from_currency, to_currency)
+		DO UPDATE SET rate = EXCLUDED.rate, updated_at = NOW()
+		WHERE exchange_rates.rate IS DISTINCT FROM EXCLUDED.rate
+		RETURNING exchange_rate_id, from_currency, to_currency, rate, created_at, updated_at
+	`
+	args := []any{fromCurrency, toCurrency, rate}
+	return query, args
+}
+
+// buildGetExchangeRateRecordQuery returns the SQL query and arguments for the stored record of a currency pair.
+func buildGetExchangeRateRecordQuery(fromCurrency, toCurrency string) (string, []any) {
+	query := `
+		SELECT exchange_rate_id, from_currency, to_currency, rate, created_at, updated_at
+		FROM exchange_rates
+		WHERE from_currency = $1 AND to_currency = $2
+	`
+	args := []any{fromCurrency, toCurrency}
+	return query, args
+}
+
+// buildDeleteExchangeRateQuery returns the SQL query and arguments for deleting a single exchange rate.
+func buildDeleteExchangeRateQuery(fromCurrency, toCurrency string) (string, []any) {
+	query := `
+		DELETE FROM exchange_rates
+		WHERE from_currency = $1 AND to_currency = $2
+	`
+	args := []any{fromCurrency, toCurrency}
+	return query, args
+}
+
+// buildInsertRateQuoteQuery returns the SQL query and arguments for recording a provider quote.
+func buildInsertRateQuoteQuery(q models.RateQuoteDB) (string, []any) {
+	query := `
+		INSERT INTO rate_quotes (source, from_currency, to_currency, rate, quoted_at, outlier, method, aggregated_rate)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	args := []any{q.Source, q.FromCurrency, q.ToCurrency, q.Rate, q.QuotedAt, q.Outlier, q.Method, q.AggregatedRate}
+	return query, args
+}
+
+// buildImportExchangeRateQuery returns the SQL upsert query and arguments for an imported exchange rate.
+// The stored rate is replaced only if the imported one is newer, so that importing it again changes nothing.
+func buildImportExchangeRateQuery(rate models.ExchangeRateHistoryDB) (string, []any) {
+	query := `
+		INSERT INTO exchange_rates (from_currency, to_currency, rate, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (from_currency, to_currency)
+		DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at
+		WHERE exchange_rates.updated_at < EXCLUDED.updated_at
+	`
+	args := []any{rate.FromCurrency, rate.ToCurrency, rate.Rate, rate.EffectiveAt}
+	return query, args
+}
+
+// buildInsertExchangeRateHistoryQuery returns the SQL query and arguments for recording
+// an exchange rate in the rate history only. A rate already recorded for the pair at the same instant is kept.
+func buildInsertExchangeRateHistoryQuery(rate models.ExchangeRateHistoryDB) (string, []any) {
+	query := `
+		INSERT INTO exchange_rate_history (from_currency, to_currency, rate, effective_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (from_currency, to_currency, effective_at) DO NOTHING
+	`
+	args := []any{rate.FromCurrency, rate.ToCurrency, rate.Rate, rate.EffectiveAt}
+	return query, args
+}
@@ -0,0 +1,361 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
+)
+
+const saveExchangeRateQuery = `INSERT INTO exchange_rates \(from_currency, to_currency, rate\) VALUES \(\$1, \$2, \$3\) ON CONFLICT \(from_currency, to_currency\) DO UPDATE SET rate = EXCLUDED.rate, updated_at = NOW\(\) WHERE exchange_rates.rate IS DISTINCT FROM EXCLUDED.rate RETURNING exchange_rate_id, from_currency, to_currency, rate, created_at, updated_at`
+
+const getExchangeRateRecordQuery = `SELECT exchange_rate_id, from_currency, to_currency, rate, created_at, updated_at FROM exchange_rates WHERE from_currency = \$1 AND to_currency = \$2`
+
+const importExchangeRateQuery = `INSERT INTO exchange_rates \(from_currency, to_currency, rate, created_at, updated_at\) VALUES \(\$1, \$2, \$3, \$4, \$4\) ON CONFLICT \(from_currency, to_currency\) DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at WHERE exchange_rates.updated_at < EXCLUDED.updated_at`
+
+const insertExchangeRateHistoryQuery = `INSERT INTO exchange_rate_history \(from_currency, to_currency, rate, effective_at\) VALUES \(\$1, \$2, \$3, \$4\) ON CONFLICT \(from_currency, to_currency, effective_at\) DO NOTHING`
+
+const insertRateQuoteQuery = `INSERT INTO rate_quotes \(source, from_currency, to_currency, rate, quoted_at, outlier, method, aggregated_rate\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\)`
+
+func exchangeRateRows(rates ...models.ExchangeRateDB) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"exchange_rate_id", "from_currency", "to_currency", "rate", "created_at", "updated_at"})
+	for _, r := range rates {
+		rows.AddRow(r.ExchangeRateID.String(), r.FromCurrency, r.ToCurrency, r.Rate.String(), r.CreatedAt, r.UpdatedAt)
+	}
+	return rows
+}
+
+func TestExchangeRateWriteRepository_Save_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	now := time.Now()
+	rate := decimal.RequireFromString("92.123456")
+	stored := models.ExchangeRateDB{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: rate, CreatedAt: now, UpdatedAt: now}
+
+	mock.ExpectQuery(saveExchangeRateQuery).
+		WithArgs("USD", "RUB", rate).
+		WillReturnRows(exchangeRateRows(stored))
+
+	got, err := repo.Save(context.Background(), "USD", "RUB", rate)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, stored.ExchangeRateID, got.ExchangeRateID)
+	assert.True(t, stored.Rate.Equal(got.Rate))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_Save_Unchanged(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	updatedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	rate := decimal.RequireFromString("92.123456")
+	stored := models.ExchangeRateDB{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: rate, CreatedAt: updatedAt, UpdatedAt: updatedAt}
+
+	// The upsert skips an equal rate, so the stored record is read unchanged.
+	mock.ExpectQuery(saveExchangeRateQuery).
+		WithArgs("USD", "RUB", rate).
+		WillReturnRows(exchangeRateRows())
+	mock.ExpectQuery(getExchangeRateRecordQuery).
+		WithArgs("USD", "RUB").
+		WillReturnRows(exchangeRateRows(stored))
+
+	got, err := repo.Save(context.Background(), "USD", "RUB", rate)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, stored.ExchangeRateID, got.ExchangeRateID)
+	assert.True(t, updatedAt.Equal(got.UpdatedAt))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_Save_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	rate := decimal.RequireFromString("92.123456")
+	mock.ExpectQuery(saveExchangeRateQuery).
+		WithArgs("USD", "RUB", rate).
+		WillReturnError(sql.ErrConnDone)
+
+	got, err := repo.Save(context.Background(), "USD", "RUB", rate)
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_SaveAll_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	now := time.Now()
+	rates := []models.ExchangeRateDB{
+		{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.123456"), CreatedAt: now, UpdatedAt: now},
+		{ExchangeRateID: uuid.New(), FromCurrency: "EUR", ToCurrency: "RUB", Rate: decimal.RequireFromString("100.1"), CreatedAt: now, UpdatedAt: now},
+	}
+
+	mock.ExpectBegin()
+	for _, r := range rates {
+		mock.ExpectQuery(saveExchangeRateQuery).
+			WithArgs(r.FromCurrency, r.ToCurrency, r.Rate).
+			WillReturnRows(exchangeRateRows(r))
+	}
+	mock.ExpectCommit()
+
+	got, err := repo.SaveAll(context.Background(), rates)
+	require.NoError(t, err)
+	require.Len(t, got, len(rates))
+	assert.Equal(t, rates[1].ExchangeRateID, got[1].ExchangeRateID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_SaveAll_RollbackOnError(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	now := time.Now()
+	rates := []models.ExchangeRateDB{
+		{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.123456"), CreatedAt: now, UpdatedAt: now},
+		{FromCurrency: "EUR", ToCurrency: "RUB", Rate: decimal.RequireFromString("100.1")},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(saveExchangeRateQuery).
+		WithArgs("USD", "RUB", rates[0].Rate).
+		WillReturnRows(exchangeRateRows(rates[0]))
+	mock.ExpectQuery(saveExchangeRateQuery).
+		WithArgs("EUR", "RUB", rates[1].Rate).
+		WillReturnError(errors.New("constraint violation"))
+	mock.ExpectRollback()
+
+	got, err := repo.SaveAll(context.Background(), rates)
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_SaveAllWithQuotes_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	now := time.Now()
+	rate := models.ExchangeRateDB{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5"), CreatedAt: now, UpdatedAt: now}
+	quotes := []models.RateQuoteDB{
+		{Source: "cbr", FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5"), QuotedAt: now, Method: "median", AggregatedRate: rate.Rate},
+		{Source: "ecb", FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("120"), QuotedAt: now, Outlier: true, Method: "median", AggregatedRate: rate.Rate},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(saveExchangeRateQuery).
+		WithArgs("USD", "RUB", rate.Rate).
+		WillReturnRows(exchangeRateRows(rate))
+	for _, q := range quotes {
+		mock.ExpectExec(insertRateQuoteQuery).
+			WithArgs(q.Source, q.FromCurrency, q.ToCurrency, q.Rate, q.QuotedAt, q.Outlier, q.Method, q.AggregatedRate).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	got, err := repo.SaveAllWithQuotes(context.Background(), []models.ExchangeRateDB{rate}, quotes)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, rate.ExchangeRateID, got[0].ExchangeRateID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_SaveAllWithQuotes_RollbackOnError(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	now := time.Now()
+	rate := models.ExchangeRateDB{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5"), CreatedAt: now, UpdatedAt: now}
+	quote := models.RateQuoteDB{Source: "cbr", FromCurrency: "USD", ToCurrency: "RUB", Rate: rate.Rate, QuotedAt: now, Method: "median", AggregatedRate: rate.Rate}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(saveExchangeRateQuery).
+		WithArgs("USD", "RUB", rate.Rate).
+		WillReturnRows(exchangeRateRows(rate))
+	mock.ExpectExec(insertRateQuoteQuery).
+		WillReturnError(errors.New("relation does not exist"))
+	mock.ExpectRollback()
+
+	got, err := repo.SaveAllWithQuotes(context.Background(), []models.ExchangeRateDB{rate}, []models.RateQuoteDB{quote})
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_Delete(t *testing.T) {
+	testCases := []struct {
+		name          string
+		result        sql.Result
+		execErr       error
+		expectDeleted bool
+		expectError   bool
+	}{
+		{name: "deleted", result: sqlmock.NewResult(0, 1), expectDeleted: true},
+		{name: "not found", result: sqlmock.NewResult(0, 0), expectDeleted: false},
+		{name: "db error", execErr: sql.ErrConnDone, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, closeFn := getMockDB(t)
+			defer closeFn()
+			logger := getLogger(t)
+
+			repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+			exp := mock.ExpectExec(`DELETE FROM exchange_rates WHERE from_currency = \$1 AND to_currency = \$2`).
+				WithArgs("USD", "RUB")
+			if tc.execErr != nil {
+				exp.WillReturnError(tc.execErr)
+			} else {
+				exp.WillReturnResult(tc.result)
+			}
+
+			deleted, err := repo.Delete(context.Background(), "USD", "RUB")
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectDeleted, deleted)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestExchangeRateWriteRepository_Import_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	rates := []models.ExchangeRateHistoryDB{
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("93"), EffectiveAt: day.Add(24 * time.Hour)},
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92"), EffectiveAt: day},
+		{FromCurrency: "EUR", ToCurrency: "RUB", Rate: decimal.RequireFromString("100"), EffectiveAt: day},
+	}
+
+	// Rates are applied oldest first; EUR->RUB already has a newer stored rate.
+	mock.ExpectBegin()
+	mock.ExpectExec(importExchangeRateQuery).
+		WithArgs("USD", "RUB", rates[1].Rate, day).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(importExchangeRateQuery).
+		WithArgs("EUR", "RUB", rates[2].Rate, day).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insertExchangeRateHistoryQuery).
+		WithArgs("EUR", "RUB", rates[2].Rate, day).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(importExchangeRateQuery).
+		WithArgs("USD", "RUB", rates[0].Rate, day.Add(24*time.Hour)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	current, err := repo.Import(context.Background(), rates)
+	require.NoError(t, err)
+	assert.Equal(t, 2, current)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_Import_Repeated(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	rates := []models.ExchangeRateHistoryDB{
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92"), EffectiveAt: day},
+	}
+
+	// The stored rate is as old as the imported one, and the history already has it.
+	mock.ExpectBegin()
+	mock.ExpectExec(importExchangeRateQuery).
+		WithArgs("USD", "RUB", rates[0].Rate, day).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insertExchangeRateHistoryQuery).
+		WithArgs("USD", "RUB", rates[0].Rate, day).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	current, err := repo.Import(context.Background(), rates)
+	require.NoError(t, err)
+	assert.Zero(t, current)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateWriteRepository_Import_RollbackOnError(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewExchangeRateWriteRepository(logger, db)
+
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	rates := []models.ExchangeRateHistoryDB{
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92"), EffectiveAt: day},
+		{FromCurrency: "EUR", ToCurrency: "RUB", Rate: decimal.RequireFromString("100"), EffectiveAt: day.Add(time.Hour)},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(importExchangeRateQuery).
+		WithArgs("USD", "RUB", rates[0].Rate, day).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(importExchangeRateQuery).
+		WithArgs("EUR", "RUB", rates[1].Rate, day.Add(time.Hour)).
+		WillReturnError(errors.New("constraint violation"))
+	mock.ExpectRollback()
+
+	current, err := repo.Import(context.Background(), rates)
+	assert.Error(t, err)
+	assert.Zero(t, current)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// RateOverrideRepository reads and writes manual rate overrides in the DB.
+type RateOverrideRepository struct {
+	db  *sqlx.DB
+	log *zap.SugaredLogger
+}
+
+// NewRateOverrideRepository creates a new repository with a logger.
+func NewRateOverrideRepository(log *zap.SugaredLogger, db *sqlx.DB) *RateOverrideRepository {
+	return &RateOverrideRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// ListActive returns the overrides that have not expired yet ordered by currency pair.
+func (r *RateOverrideRepository) ListActive(
+	ctx context.Context,
+) ([]models.RateOverrideDB, error) {
+
+	query, args := buildListActiveRateOverridesQuery()
+	var overrides []models.RateOverrideDB
+	err := r.db.SelectContext(ctx, &overrides, query, args...)
+	if err != nil {
+		r.log.Errorf("op: list rate overrides, err: %v", err)
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+// Save creates the override for a currency pair or replaces the existing one.
+// It returns the stored record.
+func (r *RateOverrideRepository) Save(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	rate decimal.Decimal,
+	reason string,
+	expiresAt time.Time,
+) (*models.RateOverrideDB, error) {
+
+	query, args := buildSaveRateOverrideQuery(fromCurrency, toCurrency, rate, reason, expiresAt)
+	var saved models.RateOverrideDB
+	err := r.db.GetContext(ctx, &saved, query, args...)
+	if err != nil {
+		r.log.Errorf("op: save rate override, err: %v", err)
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Delete removes the override for a currency pair.
+// It reports whether the pair had one.
+func (r *RateOverrideRepository) Delete(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+) (bool, error) {
+
+	query, args := buildDeleteRateOverrideQuery(fromCurrency, toCurrency)
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.log.Errorf("op: delete rate override, err: %v", err)
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		r.log.Errorf("op: delete rate override, err: %v", err)
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// buildListActiveRateOverridesQuery returns the SQL query and empty arguments for unexpired overrides.
+func buildListActiveRateOverridesQuery() (string, []any) {
+	query := `
+		SELECT rate_override_id, from_currency, to_currency, rate, reason, expires_at, created_at, updated_at
+		FROM rate_overrides
+		WHERE expires_at > NOW()
+		ORDER BY from_currency, to_currency
+	`
+	return query, nil
+}
+
+// buildSaveRateOverrideQuery returns the SQL upsert query and arguments for a single override.
+func buildSaveRateOverrideQuery(
+	fromCurrency string,
+	toCurrency string,
+	rate decimal.Decimal,
+	reason string,
+	expiresAt time.Time,
+) (string, []any) {
+	query := `
+		INSERT INTO rate_overrides (from_currency, to_currency, rate, reason, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (from_currency, to_currency)
+		DO UPDATE SET rate = EXCLUDED.rate, reason = EXCLUDED.reason, expires_at = EXCLUDED.expires_at, updated_at = NOW()
+		RETURNING rate_override_id, from_currency, to_currency, rate, reason, expires_at, created_at, updated_at
+	`
+	args := []any{fromCurrency, toCurrency, rate, reason, expiresAt}
+	return query, args
+}
+
+// buildDeleteRateOverrideQuery returns the SQL query and arguments for deleting a single override.
+func buildDeleteRateOverrideQuery(fromCurrency, toCurrency string) (string, []any) {
+	query := `
+		DELETE FROM rate_overrides
+		WHERE from_currency = $1 AND to_currency = $2
+	`
+	args := []any{fromCurrency, toCurrency}
+	return query, args
+}
@@ -0,0 +1,138 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
+)
+
+func rateOverrideRows(overrides ...models.RateOverrideDB) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"rate_override_id", "from_currency", "to_currency", "rate", "reason", "expires_at", "created_at", "updated_at"})
+	for _, o := range overrides {
+		rows.AddRow(o.RateOverrideID.String(), o.FromCurrency, o.ToCurrency, o.Rate.String(), o.Reason, o.ExpiresAt, o.CreatedAt, o.UpdatedAt)
+	}
+	return rows
+}
+
+func TestRateOverrideRepository_ListActive_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewRateOverrideRepository(logger, db)
+
+	now := time.Now()
+	overrides := []models.RateOverrideDB{
+		{RateOverrideID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("90"), Reason: "market turmoil", ExpiresAt: now.Add(time.Hour), CreatedAt: now, UpdatedAt: now},
+	}
+
+	mock.ExpectQuery(`SELECT rate_override_id, from_currency, to_currency, rate, reason, expires_at, created_at, updated_at FROM rate_overrides WHERE expires_at > NOW\(\) ORDER BY from_currency, to_currency`).
+		WillReturnRows(rateOverrideRows(overrides...))
+
+	got, err := repo.ListActive(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, overrides[0].RateOverrideID, got[0].RateOverrideID)
+	assert.True(t, overrides[0].Rate.Equal(got[0].Rate))
+	assert.Equal(t, "market turmoil", got[0].Reason)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateOverrideRepository_ListActive_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewRateOverrideRepository(logger, db)
+
+	mock.ExpectQuery(`SELECT .* FROM rate_overrides`).
+		WillReturnError(sql.ErrConnDone)
+
+	got, err := repo.ListActive(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateOverrideRepository_Save(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewRateOverrideRepository(logger, db)
+
+	now := time.Now()
+	stored := models.RateOverrideDB{RateOverrideID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("90"), Reason: "pinned", ExpiresAt: now.Add(time.Hour), CreatedAt: now, UpdatedAt: now}
+
+	mock.ExpectQuery(`INSERT INTO rate_overrides \(from_currency, to_currency, rate, reason, expires_at\) VALUES \(\$1, \$2, \$3, \$4, \$5\) ON CONFLICT \(from_currency, to_currency\) DO UPDATE SET rate = EXCLUDED.rate, reason = EXCLUDED.reason, expires_at = EXCLUDED.expires_at, updated_at = NOW\(\) RETURNING rate_override_id, from_currency, to_currency, rate, reason, expires_at, created_at, updated_at`).
+		WithArgs("USD", "RUB", stored.Rate, "pinned", stored.ExpiresAt).
+		WillReturnRows(rateOverrideRows(stored))
+
+	got, err := repo.Save(context.Background(), "USD", "RUB", stored.Rate, "pinned", stored.ExpiresAt)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, stored.RateOverrideID, got.RateOverrideID)
+
+	mock.ExpectQuery(`INSERT INTO rate_overrides`).
+		WillReturnError(sql.ErrConnDone)
+
+	got, err = repo.Save(context.Background(), "USD", "RUB", stored.Rate, "pinned", stored.ExpiresAt)
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateOverrideRepository_Delete(t *testing.T) {
+	testCases := []struct {
+		name          string
+		result        sql.Result
+		execErr       error
+		expectDeleted bool
+		expectError   bool
+	}{
+		{name: "deleted", result: sqlmock.NewResult(0, 1), expectDeleted: true},
+		{name: "not found", result: sqlmock.NewResult(0, 0), expectDeleted: false},
+		{name: "db error", execErr: sql.ErrConnDone, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, closeFn := getMockDB(t)
+			defer closeFn()
+			logger := getLogger(t)
+
+			repo := repositories.NewRateOverrideRepository(logger, db)
+
+			exp := mock.ExpectExec(`DELETE FROM rate_overrides WHERE from_currency = \$1 AND to_currency = \$2`).
+				WithArgs("USD", "RUB")
+			if tc.execErr != nil {
+				exp.WillReturnError(tc.execErr)
+			} else {
+				exp.WillReturnResult(tc.result)
+			}
+
+			deleted, err := repo.Delete(context.Background(), "USD", "RUB")
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectDeleted, deleted)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
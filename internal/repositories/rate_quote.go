@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"go.uber.org/zap"
+)
+
+// RateQuoteRepository reads the provider quotes recorded in the DB.
+type RateQuoteRepository struct {
+	db  *sqlx.DB
+	log *zap.SugaredLogger
+}
+
+// NewRateQuoteRepository creates a new repository with a logger.
+func NewRateQuoteRepository(log *zap.SugaredLogger, db *sqlx.DB) *RateQuoteRepository {
+	return &RateQuoteRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// ListLatest returns the latest quote of every source and currency pair quoted at or after since,
+// ordered by source and pair.
+func (r *RateQuoteRepository) ListLatest(
+	ctx context.Context,
+	since time.Time,
+) ([]models.RateQuoteDB, error) {
+
+	query, args := buildListLatestRateQuotesQuery(since)
+	var quotes []models.RateQuoteDB
+	err := r.db.SelectContext(ctx, &quotes, query, args...)
+	if err != nil {
+		r.log.Errorf("op: list latest rate quotes, err: %v", err)
+		return nil, err
+	}
+
+	return quotes, nil
+}
+
+// buildListLatestRateQuotesQuery returns the SQL query and arguments for the latest quote
+// of every source and currency pair.
+func buildListLatestRateQuotesQuery(since time.Time) (string, []any) {
+	query := `
+		SELECT DISTINCT ON (source, from_currency, to_currency)
+			rate_quote_id, source, from_currency, to_currency, rate, quoted_at,
+			outlier, method, aggregated_rate, created_at
+		FROM rate_quotes
+		WHERE quoted_at >= $1
+		ORDER BY source, from_currency, to_currency, quoted_at DESC
+	`
+	args := []any{since}
+	return query, args
+}
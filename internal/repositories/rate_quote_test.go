@@ -0,0 +1,57 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/repositories"
+)
+
+func TestRateQuoteRepository_ListLatest_Success(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewRateQuoteRepository(logger, db)
+
+	since := time.Date(2025, 1, 10, 6, 0, 0, 0, time.UTC)
+	quotedAt := since.Add(time.Hour)
+	mock.ExpectQuery(`SELECT DISTINCT ON \(source, from_currency, to_currency\) .* FROM rate_quotes WHERE quoted_at >= \$1 ORDER BY source, from_currency, to_currency, quoted_at DESC`).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"rate_quote_id", "source", "from_currency", "to_currency", "rate", "quoted_at", "outlier", "method", "aggregated_rate", "created_at"}).
+			AddRow(uuid.New().String(), "cbr", "USD", "RUB", "92.5", quotedAt, false, "median", "92.25", quotedAt).
+			AddRow(uuid.New().String(), "ecb", "USD", "RUB", "92", quotedAt, false, "median", "92.25", quotedAt))
+
+	got, err := repo.ListLatest(context.Background(), since)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "cbr", got[0].Source)
+	assert.Equal(t, "92.5", got[0].Rate.String())
+	assert.True(t, quotedAt.Equal(got[1].QuotedAt))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateQuoteRepository_ListLatest_Error(t *testing.T) {
+	db, mock, closeFn := getMockDB(t)
+	defer closeFn()
+	logger := getLogger(t)
+
+	repo := repositories.NewRateQuoteRepository(logger, db)
+
+	mock.ExpectQuery(`SELECT .* FROM rate_quotes`).
+		WillReturnError(sql.ErrConnDone)
+
+	got, err := repo.ListLatest(context.Background(), time.Time{})
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
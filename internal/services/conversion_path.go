@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Path length limits of FindConversionPath.
+const (
+	defaultMaxHops = 4 // used when the request does not set max_hops
+	maxHopsLimit   = 8 // upper bound of max_hops accepted from clients
+)
+
+// FindConversionPath finds a conversion path between two currencies over the graph
+// of all stored rates and returns the legs of the path and the composite rate.
+func (s *ExchangeRateService) FindConversionPath(
+	ctx context.Context,
+	req *exchangev1.ConversionPathRequest,
+) (*exchangev1.ConversionPathResponse, error) {
+
+	if err := s.currencies.validatePair(req.FromCurrency, req.ToCurrency); err != nil {
+		s.log.Errorf("op: find conversion path, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	maxHops := int(req.MaxHops)
+	if maxHops == 0 {
+		maxHops = defaultMaxHops
+	}
+	if maxHops > maxHopsLimit {
+		err := fmt.Errorf("%w: max_hops must not exceed %d: %d", ErrInvalidArgument, maxHopsLimit, req.MaxHops)
+		s.log.Errorf("op: find conversion path, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	rows, err := s.reader.List(ctx)
+	if err != nil {
+		s.log.Errorf("op: find conversion path, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	graph := newRateGraph(rows)
+
+	strategy := req.Strategy
+	var path []models.ExchangeRateDB
+	switch strategy {
+	case exchangev1.PathStrategy_PATH_STRATEGY_FRESHEST:
+		path = graph.freshestPath(req.FromCurrency, req.ToCurrency, maxHops)
+	case exchangev1.PathStrategy_PATH_STRATEGY_UNSPECIFIED, exchangev1.PathStrategy_PATH_STRATEGY_FEWEST_HOPS:
+		strategy = exchangev1.PathStrategy_PATH_STRATEGY_FEWEST_HOPS
+		path = graph.shortestPath(req.FromCurrency, req.ToCurrency, maxHops, nil)
+	default:
+		err := fmt.Errorf("%w: unsupported path strategy: %s", ErrInvalidArgument, req.Strategy)
+		s.log.Errorf("op: find conversion path, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	if path == nil {
+		err := fmt.Errorf("%w: %s -> %s within %d hops", ErrPathNotFound, req.FromCurrency, req.ToCurrency, maxHops)
+		s.log.Warnf("op: find conversion path, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	rate := decimal.NewFromInt(1)
+	legs := make([]*exchangev1.RateLeg, 0, len(path))
+	for _, r := range path {
+		rate = rate.Mul(r.Rate)
+		legs = append(legs, &exchangev1.RateLeg{
+			FromCurrency: r.FromCurrency,
+			ToCurrency:   r.ToCurrency,
+			Rate:         toDecimalPB(r.Rate),
+			UpdatedAt:    timestamppb.New(r.UpdatedAt),
+		})
+	}
+
+	return &exchangev1.ConversionPathResponse{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Rate:         toDecimalPB(rate),
+		Legs:         legs,
+		Strategy:     strategy,
+	}, nil
+}
+
+// rateGraph is a directed graph of stored rates: currency -> outgoing rates.
+type rateGraph map[string][]models.ExchangeRateDB
+
+// newRateGraph builds a rate graph. Outgoing rates are ordered by target currency
+// so that searches are deterministic.
+func newRateGraph(rates []models.ExchangeRateDB) rateGraph {
+	g := make(rateGraph)
+	for _, r := range rates {
+		g[r.FromCurrency] = append(g[r.FromCurrency], r)
+	}
+	for _, edges := range g {
+		sort.Slice(edges, func(i, j int) bool {
+			return edges[i].ToCurrency < edges[j].ToCurrency
+		})
+	}
+	return g
+}
+
+// shortestPath returns the path with the fewest hops from one currency to another,
+// using only rates accepted by allow (all rates if nil). It returns nil if there is
+// no path within maxHops.
+func (g rateGraph) shortestPath(
+	fromCurrency string,
+	toCurrency string,
+	maxHops int,
+	allow func(models.ExchangeRateDB) bool,
+) []models.ExchangeRateDB {
+
+	prev := map[string]models.ExchangeRateDB{}
+	visited := map[string]bool{fromCurrency: true}
+	frontier := []string{fromCurrency}
+
+	for hops := 0; hops < maxHops && len(frontier) > 0; hops++ {
+		var next []string
+		for _, currency := range frontier {
+			for _, edge := range g[currency] {
+				if visited[edge.ToCurrency] || (allow != nil && !allow(edge)) {
+					continue
+				}
+				visited[edge.ToCurrency] = true
+				prev[edge.ToCurrency] = edge
+				if edge.ToCurrency == toCurrency {
+					return buildPath(prev, fromCurrency, toCurrency)
+				}
+				next = append(next, edge.ToCurrency)
+			}
+		}
+		frontier = next
+	}
+
+	return nil
+}
+
+// freshestPath returns the path whose oldest rate is as recent as possible,
+// preferring fewer hops among such paths. It returns nil if there is no path within maxHops.
+func (g rateGraph) freshestPath(fromCurrency, toCurrency string, maxHops int) []models.ExchangeRateDB {
+	var thresholds []time.Time
+	for _, edges := range g {
+		for _, edge := range edges {
+			thresholds = append(thresholds, edge.UpdatedAt)
+		}
+	}
+	sort.Slice(thresholds, func(i, j int) bool {
+		return thresholds[i].After(thresholds[j])
+	})
+
+	for i, threshold := range thresholds {
+		if i > 0 && threshold.Equal(thresholds[i-1]) {
+			continue
+		}
+		path := g.shortestPath(fromCurrency, toCurrency, maxHops, func(r models.ExchangeRateDB) bool {
+			return !r.UpdatedAt.Before(threshold)
+		})
+		if path != nil {
+			return path
+		}
+	}
+
+	return nil
+}
+
+// buildPath walks prev links back from the target currency and returns the path in order.
+func buildPath(prev map[string]models.ExchangeRateDB, fromCurrency, toCurrency string) []models.ExchangeRateDB {
+	var path []models.ExchangeRateDB
+	for currency := toCurrency; currency != fromCurrency; {
+		edge := prev[currency]
+		path = append(path, edge)
+		currency = edge.FromCurrency
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// edge helper
+func edge(from, to, rate string, updatedAt time.Time) models.ExchangeRateDB {
+	return models.ExchangeRateDB{
+		FromCurrency: from,
+		ToCurrency:   to,
+		Rate:         decimal.RequireFromString(rate),
+		UpdatedAt:    updatedAt,
+	}
+}
+
+// pathString helper
+func pathString(path []models.ExchangeRateDB) []string {
+	if path == nil {
+		return nil
+	}
+	s := []string{path[0].FromCurrency}
+	for _, r := range path {
+		s = append(s, r.ToCurrency)
+	}
+	return s
+}
+
+func TestRateGraphPaths(t *testing.T) {
+	fresh := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	stale := fresh.Add(-48 * time.Hour)
+
+	graph := newRateGraph([]models.ExchangeRateDB{
+		edge("EUR", "USD", "1.08", stale),
+		edge("USD", "RUB", "92.5", stale),
+		edge("EUR", "GBP", "0.85", fresh),
+		edge("GBP", "KZT", "600", fresh),
+		edge("KZT", "RUB", "0.18", fresh),
+		edge("RUB", "EUR", "0.0099", fresh),
+	})
+
+	testCases := []struct {
+		name     string
+		find     func() []models.ExchangeRateDB
+		expected []string
+	}{
+		{
+			name:     "fewest hops",
+			find:     func() []models.ExchangeRateDB { return graph.shortestPath("EUR", "RUB", 4, nil) },
+			expected: []string{"EUR", "USD", "RUB"},
+		},
+		{
+			name:     "freshest legs",
+			find:     func() []models.ExchangeRateDB { return graph.freshestPath("EUR", "RUB", 4) },
+			expected: []string{"EUR", "GBP", "KZT", "RUB"},
+		},
+		{
+			name:     "freshest path limited by hops falls back to stale legs",
+			find:     func() []models.ExchangeRateDB { return graph.freshestPath("EUR", "RUB", 2) },
+			expected: []string{"EUR", "USD", "RUB"},
+		},
+		{
+			name:     "hop limit",
+			find:     func() []models.ExchangeRateDB { return graph.shortestPath("EUR", "RUB", 1, nil) },
+			expected: nil,
+		},
+		{
+			name:     "unreachable currency",
+			find:     func() []models.ExchangeRateDB { return graph.shortestPath("USD", "GBP", 2, nil) },
+			expected: nil,
+		},
+		{
+			name:     "path through cycle",
+			find:     func() []models.ExchangeRateDB { return graph.shortestPath("USD", "GBP", 4, nil) },
+			expected: []string{"USD", "RUB", "EUR", "GBP"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, pathString(tc.find()))
+		})
+	}
+}
+
+func TestFindConversionPath(t *testing.T) {
+	fresh := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	stale := fresh.Add(-48 * time.Hour)
+	rates := []models.ExchangeRateDB{
+		edge("EUR", "USD", "1.1", stale),
+		edge("USD", "RUB", "90", stale),
+		edge("EUR", "KZT", "500", fresh),
+		edge("KZT", "GBP", "0.0016", fresh),
+		edge("GBP", "RUB", "125", fresh),
+	}
+
+	testCases := []struct {
+		name          string
+		req           *exchangev1.ConversionPathRequest
+		mockSetup     func(m *MockExchangeRateReader)
+		expectError   bool
+		expectNilResp bool
+		expectedCode  codes.Code
+		expectedRate  string
+		expectedLegs  int
+	}{
+		{
+			name: "fewest hops by default",
+			req:  &exchangev1.ConversionPathRequest{FromCurrency: "EUR", ToCurrency: "RUB"},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(rates, nil)
+			},
+			expectedRate: "99",
+			expectedLegs: 2,
+		},
+		{
+			name: "freshest legs",
+			req: &exchangev1.ConversionPathRequest{
+				FromCurrency: "EUR",
+				ToCurrency:   "RUB",
+				Strategy:     exchangev1.PathStrategy_PATH_STRATEGY_FRESHEST,
+			},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(rates, nil)
+			},
+			expectedRate: "100",
+			expectedLegs: 3,
+		},
+		{
+			name: "path not found",
+			req:  &exchangev1.ConversionPathRequest{FromCurrency: "RUB", ToCurrency: "EUR"},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(rates, nil)
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name: "reader returns error",
+			req:  &exchangev1.ConversionPathRequest{FromCurrency: "EUR", ToCurrency: "RUB"},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name:          "max hops over limit",
+			req:           &exchangev1.ConversionPathRequest{FromCurrency: "EUR", ToCurrency: "RUB", MaxHops: 100},
+			mockSetup:     func(m *MockExchangeRateReader) {},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name:          "unsupported currency",
+			req:           &exchangev1.ConversionPathRequest{FromCurrency: "EUR", ToCurrency: "JPY"},
+			mockSetup:     func(m *MockExchangeRateReader) {},
+			expectError:   true,
+			expectNilResp: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReader := NewMockExchangeRateReader(ctrl)
+			tc.mockSetup(mockReader)
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+
+			resp, err := svc.FindConversionPath(context.Background(), tc.req)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				if tc.expectedCode != codes.OK {
+					assert.Equal(t, tc.expectedCode, status.Code(err))
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tc.expectNilResp {
+				assert.Nil(t, resp)
+				return
+			}
+
+			require.NotNil(t, resp)
+			assert.True(t, decimal.RequireFromString(tc.expectedRate).Equal(decimal.RequireFromString(resp.Rate.Value)), "got %s", resp.Rate.Value)
+			assert.Len(t, resp.Legs, tc.expectedLegs)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConvertAmount converts an amount between two currencies and rounds the result
+// to the minor units of the target currency using the requested rounding mode.
+func (s *ExchangeRateService) ConvertAmount(
+	ctx context.Context,
+	req *exchangev1.ConvertAmountRequest,
+) (*exchangev1.ConvertAmountResponse, error) {
+
+	if err := s.currencies.validatePair(req.FromCurrency, req.ToCurrency); err != nil {
+		s.log.Errorf("op: convert amount, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	if req.Amount == nil {
+		err := fmt.Errorf("%w: amount is required", ErrInvalidArgument)
+		s.log.Errorf("op: convert amount, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	amount, err := fromDecimalPB(req.Amount)
+	if err != nil {
+		err = fmt.Errorf("%w: amount: %w", ErrInvalidArgument, err)
+		s.log.Errorf("op: convert amount, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	mode := req.RoundingMode
+	if mode == exchangev1.RoundingMode_ROUNDING_MODE_UNSPECIFIED {
+		mode = exchangev1.RoundingMode_ROUNDING_MODE_HALF_EVEN
+	}
+	target, _ := s.currencies.Get(req.ToCurrency)
+	minorUnits := target.MinorUnits
+
+	asOf := time.Now().UTC()
+	var at *time.Time
+	if req.AsOf != nil {
+		if err := req.AsOf.CheckValid(); err != nil {
+			err = fmt.Errorf("%w: as_of: %w", ErrInvalidArgument, err)
+			s.log.Errorf("op: convert amount, err: %v", err)
+			return nil, toStatusError(err)
+		}
+		asOf = req.AsOf.AsTime()
+		at = &asOf
+	}
+
+	resolved, err := s.resolveRate(ctx, req.FromCurrency, req.ToCurrency, at)
+	if err != nil {
+		s.log.Errorf("op: convert amount, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	if resolved == nil {
+		err := fmt.Errorf("%w: %s -> %s as of %s", ErrRateNotFound, req.FromCurrency, req.ToCurrency, asOf)
+		s.log.Warnf("op: convert amount, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	converted, err := roundAmount(amount.Mul(resolved.rate), minorUnits, mode)
+	if err != nil {
+		s.log.Errorf("op: convert amount, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	return &exchangev1.ConvertAmountResponse{
+		FromCurrency:    req.FromCurrency,
+		ToCurrency:      req.ToCurrency,
+		Amount:          toDecimalPB(amount),
+		ConvertedAmount: toFixedDecimalPB(converted, minorUnits),
+		Rate:            toDecimalPB(resolved.rate),
+		MinorUnits:      minorUnits,
+		RoundingMode:    mode,
+		Derived:         resolved.derived,
+		AsOf:            timestamppb.New(asOf),
+	}, nil
+}
+
+// roundAmount rounds an amount to the given number of decimal places.
+func roundAmount(amount decimal.Decimal, places int32, mode exchangev1.RoundingMode) (decimal.Decimal, error) {
+	switch mode {
+	case exchangev1.RoundingMode_ROUNDING_MODE_HALF_EVEN:
+		return amount.RoundBank(places), nil
+	case exchangev1.RoundingMode_ROUNDING_MODE_HALF_UP:
+		return amount.Round(places), nil
+	case exchangev1.RoundingMode_ROUNDING_MODE_DOWN:
+		return amount.Truncate(places), nil
+	default:
+		return decimal.Zero, fmt.Errorf("%w: unsupported rounding mode: %s", ErrInvalidArgument, mode)
+	}
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRoundAmount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   string
+		places   int32
+		mode     exchangev1.RoundingMode
+		expected string
+	}{
+		{name: "half even rounds tie to even down", amount: "10.125", places: 2, mode: exchangev1.RoundingMode_ROUNDING_MODE_HALF_EVEN, expected: "10.12"},
+		{name: "half even rounds tie to even up", amount: "10.135", places: 2, mode: exchangev1.RoundingMode_ROUNDING_MODE_HALF_EVEN, expected: "10.14"},
+		{name: "half up rounds tie away from zero", amount: "10.125", places: 2, mode: exchangev1.RoundingMode_ROUNDING_MODE_HALF_UP, expected: "10.13"},
+		{name: "half up negative", amount: "-10.125", places: 2, mode: exchangev1.RoundingMode_ROUNDING_MODE_HALF_UP, expected: "-10.13"},
+		{name: "down truncates", amount: "10.129", places: 2, mode: exchangev1.RoundingMode_ROUNDING_MODE_DOWN, expected: "10.12"},
+		{name: "down truncates negative toward zero", amount: "-10.129", places: 2, mode: exchangev1.RoundingMode_ROUNDING_MODE_DOWN, expected: "-10.12"},
+		{name: "zero minor units", amount: "1234.5", places: 0, mode: exchangev1.RoundingMode_ROUNDING_MODE_HALF_EVEN, expected: "1234"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := roundAmount(decimal.RequireFromString(tc.amount), tc.places, tc.mode)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got.String())
+		})
+	}
+
+	_, err := roundAmount(decimal.NewFromInt(1), 2, exchangev1.RoundingMode(42))
+	assert.Error(t, err)
+}
+
+func TestConvertAmount(t *testing.T) {
+	testCases := []struct {
+		name             string
+		req              *exchangev1.ConvertAmountRequest
+		mockSetup        func(m *MockExchangeRateReader)
+		expectError      bool
+		expectNilResp    bool
+		expectedCode     codes.Code
+		expectedAmount   string
+		expectedRounding exchangev1.RoundingMode
+	}{
+		{
+			name: "converted and rounded half even by default",
+			req: &exchangev1.ConvertAmountRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Amount:       &exchangev1.Decimal{Value: "10.5"},
+			},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("92.123456"), nil)
+			},
+			expectedAmount:   "967.30",
+			expectedRounding: exchangev1.RoundingMode_ROUNDING_MODE_HALF_EVEN,
+		},
+		{
+			name: "rounded down",
+			req: &exchangev1.ConvertAmountRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Amount:       &exchangev1.Decimal{Units: 1},
+				RoundingMode: exchangev1.RoundingMode_ROUNDING_MODE_DOWN,
+			},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("92.129"), nil)
+			},
+			expectedAmount:   "92.12",
+			expectedRounding: exchangev1.RoundingMode_ROUNDING_MODE_DOWN,
+		},
+		{
+			name: "rate not found",
+			req: &exchangev1.ConvertAmountRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "EUR",
+				Amount:       &exchangev1.Decimal{Value: "1"},
+			},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "USD", "EUR").Return(nil, nil)
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name: "reader returns error",
+			req: &exchangev1.ConvertAmountRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Amount:       &exchangev1.Decimal{Value: "1"},
+			},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(nil, errors.New("db error"))
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name:          "amount is required",
+			req:           &exchangev1.ConvertAmountRequest{FromCurrency: "USD", ToCurrency: "RUB"},
+			mockSetup:     func(m *MockExchangeRateReader) {},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name: "invalid amount",
+			req: &exchangev1.ConvertAmountRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Amount:       &exchangev1.Decimal{Value: "ten"},
+			},
+			mockSetup:     func(m *MockExchangeRateReader) {},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name: "unsupported rounding mode",
+			req: &exchangev1.ConvertAmountRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Amount:       &exchangev1.Decimal{Value: "1"},
+				RoundingMode: exchangev1.RoundingMode(42),
+			},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("92.5"), nil)
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name: "unsupported currency",
+			req: &exchangev1.ConvertAmountRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "JPY",
+				Amount:       &exchangev1.Decimal{Value: "1"},
+			},
+			mockSetup:     func(m *MockExchangeRateReader) {},
+			expectError:   true,
+			expectNilResp: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReader := NewMockExchangeRateReader(ctrl)
+			tc.mockSetup(mockReader)
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+
+			resp, err := svc.ConvertAmount(context.Background(), tc.req)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				if tc.expectedCode != codes.OK {
+					assert.Equal(t, tc.expectedCode, status.Code(err))
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tc.expectNilResp {
+				assert.Nil(t, resp)
+				return
+			}
+
+			require.NotNil(t, resp)
+			assert.Equal(t, tc.expectedAmount, resp.ConvertedAmount.Value)
+			assert.Equal(t, int32(2), resp.MinorUnits)
+			assert.Equal(t, tc.expectedRounding, resp.RoundingMode)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"go.uber.org/zap"
+)
+
+// CurrencyReader is an interface for reading the currency reference table.
+type CurrencyReader interface {
+	List(ctx context.Context) ([]models.CurrencyDB, error)
+}
+
+// currencySnapshot is an immutable copy of the currency table.
+type currencySnapshot struct {
+	currencies []models.CurrencyDB // ordered by code
+	byCode     map[string]models.CurrencyDB
+}
+
+// CurrencyRegistry keeps the currency reference table in memory so that requests
+// are validated without a DB round trip. The table is reloaded periodically,
+// so currencies enabled in the DB become available without a release.
+type CurrencyRegistry struct {
+	reader         CurrencyReader
+	reloadInterval time.Duration
+	snapshot       atomic.Pointer[currencySnapshot]
+	log            *zap.SugaredLogger
+}
+
+// NewCurrencyRegistry creates a new registry over the reader.
+// reloadInterval is the period of the reload; zero disables it.
+// The registry is empty until the first Reload.
+func NewCurrencyRegistry(
+	log *zap.SugaredLogger,
+	reader CurrencyReader,
+	reloadInterval time.Duration,
+) *CurrencyRegistry {
+	return &CurrencyRegistry{
+		reader:         reader,
+		reloadInterval: reloadInterval,
+		log:            log,
+	}
+}
+
+// Reload loads the currency table from the reader and swaps the in-memory copy.
+// On error the previous copy is kept.
+func (r *CurrencyRegistry) Reload(ctx context.Context) error {
+	currencies, err := r.reader.List(ctx)
+	if err != nil {
+		r.log.Errorf("op: reload currencies, err: %v", err)
+		return err
+	}
+
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i].Code < currencies[j].Code
+	})
+	byCode := make(map[string]models.CurrencyDB, len(currencies))
+	for _, c := range currencies {
+		byCode[c.Code] = c
+	}
+
+	r.snapshot.Store(&currencySnapshot{
+		currencies: currencies,
+		byCode:     byCode,
+	})
+	r.log.Debugf("op: reload currencies, currencies: %d", len(currencies))
+
+	return nil
+}
+
+// Run reloads the currency table every reload interval until ctx is done.
+func (r *CurrencyRegistry) Run(ctx context.Context) {
+	if r.reloadInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Reload(ctx)
+		}
+	}
+}
+
+// Get returns a currency by its ISO 4217 code regardless of its enabled flag.
+func (r *CurrencyRegistry) Get(code string) (models.CurrencyDB, bool) {
+	snap := r.snapshot.Load()
+	if snap == nil {
+		return models.CurrencyDB{}, false
+	}
+
+	c, ok := snap.byCode[code]
+	return c, ok
+}
+
+// List returns all currencies ordered by code regardless of their enabled flag.
+func (r *CurrencyRegistry) List() []models.CurrencyDB {
+	snap := r.snapshot.Load()
+	if snap == nil {
+		return nil
+	}
+
+	currencies := make([]models.CurrencyDB, len(snap.currencies))
+	copy(currencies, snap.currencies)
+	return currencies
+}
+
+// Supported reports whether a currency is known and enabled.
+func (r *CurrencyRegistry) Supported(code string) bool {
+	c, ok := r.Get(code)
+	return ok && c.Enabled
+}
+
+// validatePair checks that both currencies are supported and differ from each other.
+func (r *CurrencyRegistry) validatePair(fromCurrency, toCurrency string) error {
+	if !r.Supported(fromCurrency) {
+		return fmt.Errorf("%w: from %s", ErrUnsupportedCurrency, fromCurrency)
+	}
+	if !r.Supported(toCurrency) {
+		return fmt.Errorf("%w: to %s", ErrUnsupportedCurrency, toCurrency)
+	}
+	if fromCurrency == toCurrency {
+		return fmt.Errorf("%w: from and to currencies must differ: %s", ErrInvalidArgument, fromCurrency)
+	}
+	return nil
+}
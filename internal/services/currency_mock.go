@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /home/sergey/Github/gw-exchanger/internal/services/currency.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/sbilibin2017/gw-exchanger/internal/models"
+)
+
+// MockCurrencyReader is a mock of CurrencyReader interface.
+type MockCurrencyReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyReaderMockRecorder
+}
+
+// MockCurrencyReaderMockRecorder is the mock recorder for MockCurrencyReader.
+type MockCurrencyReaderMockRecorder struct {
+	mock *MockCurrencyReader
+}
+
+// NewMockCurrencyReader creates a new mock instance.
+func NewMockCurrencyReader(ctrl *gomock.Controller) *MockCurrencyReader {
+	mock := &MockCurrencyReader{ctrl: ctrl}
+	mock.recorder = &MockCurrencyReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyReader) EXPECT() *MockCurrencyReaderMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockCurrencyReader) List(ctx context.Context) ([]models.CurrencyDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.CurrencyDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCurrencyReaderMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCurrencyReader)(nil).List), ctx)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testCurrencies is the currency table used by service tests.
+var testCurrencies = []models.CurrencyDB{
+	{Code: "EUR", NumericCode: 978, Name: "Euro", MinorUnits: 2, Enabled: true},
+	{Code: "KZT", NumericCode: 398, Name: "Tenge", MinorUnits: 2, Enabled: false},
+	{Code: "RUB", NumericCode: 643, Name: "Russian Ruble", MinorUnits: 2, Enabled: true},
+	{Code: "USD", NumericCode: 840, Name: "US Dollar", Symbol: "$", MinorUnits: 2, Enabled: true},
+}
+
+// newTestCurrencyRegistry returns a registry loaded with testCurrencies.
+func newTestCurrencyRegistry(t *testing.T) *CurrencyRegistry {
+	ctrl := gomock.NewController(t)
+	mockReader := NewMockCurrencyReader(ctrl)
+	mockReader.EXPECT().List(gomock.Any()).Return(testCurrencies, nil)
+
+	registry := NewCurrencyRegistry(zap.NewNop().Sugar(), mockReader, 0)
+	require.NoError(t, registry.Reload(context.Background()))
+	return registry
+}
+
+func TestCurrencyRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := NewMockCurrencyReader(ctrl)
+	registry := NewCurrencyRegistry(zap.NewNop().Sugar(), mockReader, 0)
+
+	// Nothing is supported until the first reload.
+	assert.False(t, registry.Supported("USD"))
+
+	mockReader.EXPECT().List(gomock.Any()).Return(testCurrencies, nil)
+	require.NoError(t, registry.Reload(context.Background()))
+
+	assert.True(t, registry.Supported("USD"))
+	assert.False(t, registry.Supported("KZT"), "disabled currency")
+	assert.False(t, registry.Supported("GBP"), "unknown currency")
+
+	kzt, ok := registry.Get("KZT")
+	require.True(t, ok)
+	assert.Equal(t, int32(398), kzt.NumericCode)
+
+	// A failed reload keeps the previous table.
+	mockReader.EXPECT().List(gomock.Any()).Return(nil, errors.New("db error"))
+	assert.Error(t, registry.Reload(context.Background()))
+	assert.True(t, registry.Supported("USD"))
+
+	// Enabling a currency in the DB makes it supported after the next reload.
+	updated := make([]models.CurrencyDB, len(testCurrencies))
+	copy(updated, testCurrencies)
+	for i := range updated {
+		if updated[i].Code == "KZT" {
+			updated[i].Enabled = true
+		}
+	}
+	mockReader.EXPECT().List(gomock.Any()).Return(updated, nil)
+	require.NoError(t, registry.Reload(context.Background()))
+	assert.True(t, registry.Supported("KZT"))
+}
+
+func TestCurrencyRegistryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reloaded := make(chan struct{}, 1)
+	mockReader := NewMockCurrencyReader(ctrl)
+	mockReader.EXPECT().List(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]models.CurrencyDB, error) {
+		select {
+		case reloaded <- struct{}{}:
+		default:
+		}
+		return testCurrencies, nil
+	}).MinTimes(1)
+
+	registry := NewCurrencyRegistry(zap.NewNop().Sugar(), mockReader, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		registry.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("registry was not reloaded")
+	}
+	cancel()
+	<-done
+
+	assert.True(t, registry.Supported("EUR"))
+}
+
+func TestValidatePair(t *testing.T) {
+	registry := newTestCurrencyRegistry(t)
+
+	testCases := []struct {
+		name        string
+		from        string
+		to          string
+		expectedErr error
+	}{
+		{name: "disabled to currency", from: "USD", to: "KZT", expectedErr: ErrUnsupportedCurrency},
+		{name: "enabled pair", from: "USD", to: "EUR"},
+		{name: "unknown from currency", from: "GBP", to: "USD", expectedErr: ErrUnsupportedCurrency},
+		{name: "same currency", from: "USD", to: "USD", expectedErr: ErrInvalidArgument},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := registry.validatePair(tc.from, tc.to)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
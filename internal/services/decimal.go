@@ -0,0 +1,61 @@
+package services
+
+import (
+	"fmt"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+// nanosExp is the decimal exponent of the nanos part of exchangev1.Decimal.
+const nanosExp = 9
+
+// toDecimalPB converts an exact decimal to its protobuf representation.
+// Digits beyond the ninth fractional place are truncated in nanos but kept in value.
+func toDecimalPB(d decimal.Decimal) *exchangev1.Decimal {
+	units := d.Truncate(0)
+	return &exchangev1.Decimal{
+		Value: d.String(),
+		Units: units.IntPart(),
+		Nanos: int32(d.Sub(units).Shift(nanosExp).IntPart()),
+	}
+}
+
+// toFixedDecimalPB converts an exact decimal to its protobuf representation
+// with the string value padded to exactly places fractional digits (e.g. "92.50").
+func toFixedDecimalPB(d decimal.Decimal, places int32) *exchangev1.Decimal {
+	pb := toDecimalPB(d)
+	pb.Value = d.StringFixed(places)
+	return pb
+}
+
+// fromDecimalPB converts a protobuf decimal to an exact decimal.
+// The string value takes precedence over units and nanos when set.
+func fromDecimalPB(d *exchangev1.Decimal) (decimal.Decimal, error) {
+	if d.Value != "" {
+		v, err := decimal.NewFromString(d.Value)
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("invalid decimal value %q: %w", d.Value, err)
+		}
+		return v, nil
+	}
+
+	if d.Nanos <= -1e9 || d.Nanos >= 1e9 {
+		return decimal.Zero, fmt.Errorf("invalid decimal nanos: %d", d.Nanos)
+	}
+	if (d.Units > 0 && d.Nanos < 0) || (d.Units < 0 && d.Nanos > 0) {
+		return decimal.Zero, fmt.Errorf("decimal units and nanos must have the same sign: %d, %d", d.Units, d.Nanos)
+	}
+
+	return decimal.NewFromInt(d.Units).Add(decimal.New(int64(d.Nanos), -nanosExp)), nil
+}
+
+// validateStoredRate checks that a positive rate fits DECIMAL(18,6) exactly,
+// so that it is neither rounded nor rejected by the database.
+func validateStoredRate(rate decimal.Decimal) error {
+	if err := models.ValidateRate(rate); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToDecimalPB(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected *exchangev1.Decimal
+	}{
+		{name: "positive", value: "92.123456", expected: &exchangev1.Decimal{Value: "92.123456", Units: 92, Nanos: 123456000}},
+		{name: "integer", value: "100", expected: &exchangev1.Decimal{Value: "100", Units: 100, Nanos: 0}},
+		{name: "less than one", value: "0.010856", expected: &exchangev1.Decimal{Value: "0.010856", Units: 0, Nanos: 10856000}},
+		{name: "negative", value: "-1.5", expected: &exchangev1.Decimal{Value: "-1.5", Units: -1, Nanos: -500000000}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := toDecimalPB(decimal.RequireFromString(tc.value))
+			assert.Equal(t, tc.expected.Value, got.Value)
+			assert.Equal(t, tc.expected.Units, got.Units)
+			assert.Equal(t, tc.expected.Nanos, got.Nanos)
+		})
+	}
+}
+
+func TestFromDecimalPB(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       *exchangev1.Decimal
+		expected    string
+		expectError bool
+	}{
+		{name: "string value", input: &exchangev1.Decimal{Value: "92.123456"}, expected: "92.123456"},
+		{name: "units and nanos", input: &exchangev1.Decimal{Units: 92, Nanos: 123456000}, expected: "92.123456"},
+		{name: "value takes precedence", input: &exchangev1.Decimal{Value: "1.1", Units: 2}, expected: "1.1"},
+		{name: "invalid value", input: &exchangev1.Decimal{Value: "abc"}, expectError: true},
+		{name: "nanos out of range", input: &exchangev1.Decimal{Units: 1, Nanos: 1e9}, expectError: true},
+		{name: "mismatched signs", input: &exchangev1.Decimal{Units: 1, Nanos: -1}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := fromDecimalPB(tc.input)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, decimal.RequireFromString(tc.expected).Equal(got), "got %s", got)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of google.rpc.ErrorInfo details attached to errors returned by the services.
+const ErrorDomain = "gw-exchanger"
+
+// Stable google.rpc.ErrorInfo reasons clients can branch on.
+const (
+	ReasonUnsupportedCurrency = "UNSUPPORTED_CURRENCY"
+	ReasonInvalidArgument     = "INVALID_ARGUMENT"
+	ReasonRateNotFound        = "RATE_NOT_FOUND"
+	ReasonPathNotFound        = "CONVERSION_PATH_NOT_FOUND"
+	ReasonAsymmetricRate      = "ASYMMETRIC_RATE"
+	ReasonConstraintViolation = "CONSTRAINT_VIOLATION"
+	ReasonStorageTimeout      = "STORAGE_TIMEOUT"
+	ReasonStorageUnavailable  = "STORAGE_UNAVAILABLE"
+	ReasonCanceled            = "CANCELED"
+	ReasonStreamClosed        = "STREAM_CLOSED"
+	ReasonInternal            = "INTERNAL"
+)
+
+// Sentinel errors classifying failures of the services.
+var (
+	// ErrUnsupportedCurrency is returned for currencies the service does not support.
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	// ErrInvalidArgument is returned for malformed requests.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrRateNotFound is returned when no rate is stored or can be derived for a pair.
+	ErrRateNotFound = errors.New("exchange rate not found")
+	// ErrPathNotFound is returned when no conversion path connects two currencies.
+	ErrPathNotFound = errors.New("conversion path not found")
+)
+
+// toStatusError converts an error to a gRPC status error with a google.rpc.ErrorInfo detail.
+// Storage failures are reported with a generic message so that driver details do not leak to clients.
+func toStatusError(err error) error {
+	code, reason := classifyError(err)
+
+	msg := err.Error()
+	switch reason {
+	case ReasonStorageTimeout:
+		msg = "storage timeout"
+	case ReasonStorageUnavailable:
+		msg = "storage unavailable"
+	case ReasonConstraintViolation:
+		msg = "value rejected by storage constraints"
+	case ReasonInternal:
+		msg = "internal error"
+	}
+
+	st := status.New(code, msg)
+	if detailed, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	}); detailsErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// classifyError maps an error to a gRPC code and an ErrorInfo reason.
+func classifyError(err error) (codes.Code, string) {
+	switch {
+	case errors.Is(err, ErrUnsupportedCurrency):
+		return codes.InvalidArgument, ReasonUnsupportedCurrency
+	case errors.Is(err, ErrInvalidArgument):
+		return codes.InvalidArgument, ReasonInvalidArgument
+	case errors.Is(err, ErrRateNotFound):
+		return codes.NotFound, ReasonRateNotFound
+	case errors.Is(err, ErrPathNotFound):
+		return codes.NotFound, ReasonPathNotFound
+	case errors.Is(err, ErrAsymmetricRate):
+		return codes.FailedPrecondition, ReasonAsymmetricRate
+	case errors.Is(err, ErrRateHubClosed):
+		return codes.Unavailable, ReasonStreamClosed
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded, ReasonStorageTimeout
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled, ReasonCanceled
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return codes.Unavailable, ReasonStorageUnavailable
+	}
+
+	// PostgreSQL errors expose their SQLSTATE code.
+	var sqlErr interface{ SQLState() string }
+	if errors.As(err, &sqlErr) {
+		state := sqlErr.SQLState()
+		switch {
+		case state == "57014": // query_canceled, e.g. statement_timeout
+			return codes.DeadlineExceeded, ReasonStorageTimeout
+		case state == "22003", strings.HasPrefix(state, "23"): // numeric value out of range, integrity constraint violation
+			return codes.InvalidArgument, ReasonConstraintViolation
+		case strings.HasPrefix(state, "08"), strings.HasPrefix(state, "53"): // connection exception, insufficient resources
+			return codes.Unavailable, ReasonStorageUnavailable
+		case strings.HasPrefix(state, "57P"): // operator intervention: shutdown, cannot connect now
+			return codes.Unavailable, ReasonStorageUnavailable
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return codes.DeadlineExceeded, ReasonStorageTimeout
+		}
+		return codes.Unavailable, ReasonStorageUnavailable
+	}
+
+	return codes.Internal, ReasonInternal
+}
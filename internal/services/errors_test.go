@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sqlStateError mimics a PostgreSQL driver error exposing its SQLSTATE code.
+type sqlStateError struct {
+	state string
+}
+
+func (e sqlStateError) Error() string    { return "pg error " + e.state }
+func (e sqlStateError) SQLState() string { return e.state }
+
+func TestToStatusError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedCode   codes.Code
+		expectedReason string
+		expectedMsg    string
+	}{
+		{
+			name:           "unsupported currency",
+			err:            fmt.Errorf("%w: from GBP", ErrUnsupportedCurrency),
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: ReasonUnsupportedCurrency,
+			expectedMsg:    "unsupported currency: from GBP",
+		},
+		{
+			name:           "invalid argument",
+			err:            fmt.Errorf("%w: amount is required", ErrInvalidArgument),
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: ReasonInvalidArgument,
+			expectedMsg:    "invalid argument: amount is required",
+		},
+		{
+			name:           "rate not found",
+			err:            fmt.Errorf("%w: USD -> EUR", ErrRateNotFound),
+			expectedCode:   codes.NotFound,
+			expectedReason: ReasonRateNotFound,
+			expectedMsg:    "exchange rate not found: USD -> EUR",
+		},
+		{
+			name:           "path not found",
+			err:            fmt.Errorf("%w: USD -> EUR within 4 hops", ErrPathNotFound),
+			expectedCode:   codes.NotFound,
+			expectedReason: ReasonPathNotFound,
+			expectedMsg:    "conversion path not found: USD -> EUR within 4 hops",
+		},
+		{
+			name:           "asymmetric rate",
+			err:            fmt.Errorf("%w: USD -> RUB", ErrAsymmetricRate),
+			expectedCode:   codes.FailedPrecondition,
+			expectedReason: ReasonAsymmetricRate,
+			expectedMsg:    "asymmetric exchange rate pair: USD -> RUB",
+		},
+		{
+			name:           "rate hub closed",
+			err:            ErrRateHubClosed,
+			expectedCode:   codes.Unavailable,
+			expectedReason: ReasonStreamClosed,
+			expectedMsg:    "rate hub closed",
+		},
+		{
+			name:           "context deadline",
+			err:            fmt.Errorf("query: %w", context.DeadlineExceeded),
+			expectedCode:   codes.DeadlineExceeded,
+			expectedReason: ReasonStorageTimeout,
+			expectedMsg:    "storage timeout",
+		},
+		{
+			name:           "context canceled",
+			err:            context.Canceled,
+			expectedCode:   codes.Canceled,
+			expectedReason: ReasonCanceled,
+			expectedMsg:    "context canceled",
+		},
+		{
+			name:           "statement timeout",
+			err:            sqlStateError{state: "57014"},
+			expectedCode:   codes.DeadlineExceeded,
+			expectedReason: ReasonStorageTimeout,
+			expectedMsg:    "storage timeout",
+		},
+		{
+			name:           "connection exception",
+			err:            sqlStateError{state: "08006"},
+			expectedCode:   codes.Unavailable,
+			expectedReason: ReasonStorageUnavailable,
+			expectedMsg:    "storage unavailable",
+		},
+		{
+			name:           "database shutting down",
+			err:            sqlStateError{state: "57P01"},
+			expectedCode:   codes.Unavailable,
+			expectedReason: ReasonStorageUnavailable,
+			expectedMsg:    "storage unavailable",
+		},
+		{
+			name:           "bad connection",
+			err:            driver.ErrBadConn,
+			expectedCode:   codes.Unavailable,
+			expectedReason: ReasonStorageUnavailable,
+			expectedMsg:    "storage unavailable",
+		},
+		{
+			name:           "connection refused",
+			err:            &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			expectedCode:   codes.Unavailable,
+			expectedReason: ReasonStorageUnavailable,
+			expectedMsg:    "storage unavailable",
+		},
+		{
+			name:           "unclassified error",
+			err:            errors.New("db error"),
+			expectedCode:   codes.Internal,
+			expectedReason: ReasonInternal,
+			expectedMsg:    "internal error",
+		},
+		{
+			name:           "numeric value out of range",
+			err:            fmt.Errorf("save: %w", sqlStateError{state: "22003"}),
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: ReasonConstraintViolation,
+			expectedMsg:    "value rejected by storage constraints",
+		},
+		{
+			name:           "check constraint violation",
+			err:            sqlStateError{state: "23514"},
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: ReasonConstraintViolation,
+			expectedMsg:    "value rejected by storage constraints",
+		},
+		{
+			name:           "unclassified sql state",
+			err:            sqlStateError{state: "42P01"},
+			expectedCode:   codes.Internal,
+			expectedReason: ReasonInternal,
+			expectedMsg:    "internal error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st, ok := status.FromError(toStatusError(tc.err))
+			require.True(t, ok)
+
+			assert.Equal(t, tc.expectedCode, st.Code())
+			assert.Equal(t, tc.expectedMsg, st.Message())
+
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedReason, info.Reason)
+			assert.Equal(t, ErrorDomain, info.Domain)
+		})
+	}
+}
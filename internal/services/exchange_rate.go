@@ -3,82 +3,133 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
 	"github.com/sbilibin2017/gw-exchanger/internal/models"
 	pb "github.com/sbilibin2017/proto-exchange/exchange"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// Constants for supported currencies
-const (
-	usd = "USD"
-	rub = "RUB"
-	eur = "EUR"
-)
-
-// supportedCurrencies is a map of valid currencies for quick lookup
-var supportedCurrencies = map[string]struct{}{
-	usd: {},
-	rub: {},
-	eur: {},
-}
+// AsOfMetadataKey is the request metadata key with the RFC 3339 instant
+// GetExchangeRateForCurrency returns the rate for. Its request message
+// belongs to the shared proto and has no as_of field.
+const AsOfMetadataKey = "as-of"
 
 // ExchangeRateReader is an interface for reading currency exchange rates.
 type ExchangeRateReader interface {
-	Get(ctx context.Context, fromCurrency, toCurrency string) (*float64, error)
+	Get(ctx context.Context, fromCurrency, toCurrency string) (*decimal.Decimal, error)
+	GetAt(ctx context.Context, fromCurrency, toCurrency string, asOf time.Time) (*decimal.Decimal, error)
 	List(ctx context.Context) ([]models.ExchangeRateDB, error)
 }
 
 // ExchangeRateService implements the gRPC server for currency exchange rates.
 type ExchangeRateService struct {
 	pb.UnimplementedExchangeServiceServer
-	reader ExchangeRateReader
-	log    *zap.SugaredLogger
+	exchangev1.UnimplementedRateServiceServer
+	reader           ExchangeRateReader
+	currencies       *CurrencyRegistry
+	hub              *RateHub
+	history          ExchangeRateHistoryReader
+	overrides        *RateOverrides
+	pivotCurrency    string
+	inversePolicy    InversePolicy
+	inverseTolerance decimal.Decimal
+	log              *zap.SugaredLogger
+}
+
+// ExchangeRateServiceOption configures optional behaviour of ExchangeRateService.
+type ExchangeRateServiceOption func(*ExchangeRateService)
+
+// WithPivotCurrency enables derivation of missing pairs through the given pivot currency:
+// if FROM->TO is not stored, it is computed as FROM->PIVOT * PIVOT->TO.
+func WithPivotCurrency(currency string) ExchangeRateServiceOption {
+	return func(s *ExchangeRateService) {
+		s.pivotCurrency = currency
+	}
+}
+
+// WithInversePolicy enables derivation of rates from the opposite direction of a pair.
+// tolerance is the allowed deviation of the product of both directions from 1
+// under InversePolicyStrict.
+func WithInversePolicy(policy InversePolicy, tolerance decimal.Decimal) ExchangeRateServiceOption {
+	return func(s *ExchangeRateService) {
+		s.inversePolicy = policy
+		s.inverseTolerance = tolerance
+	}
+}
+
+// WithRateHub enables SubscribeRates streaming fed by the given hub.
+func WithRateHub(hub *RateHub) ExchangeRateServiceOption {
+	return func(s *ExchangeRateService) {
+		s.hub = hub
+	}
 }
 
 // NewExchangeRateService creates a new instance of ExchangeRateService.
 func NewExchangeRateService(
 	log *zap.SugaredLogger,
 	reader ExchangeRateReader,
+	currencies *CurrencyRegistry,
+	opts ...ExchangeRateServiceOption,
 ) *ExchangeRateService {
-	return &ExchangeRateService{
-		reader: reader,
-		log:    log,
+	s := &ExchangeRateService{
+		reader:     reader,
+		currencies: currencies,
+		log:        log,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetExchangeRateForCurrency returns the exchange rate for a specific currency pair.
+// If the as-of request metadata is set, the rate effective at that instant is returned.
 func (s *ExchangeRateService) GetExchangeRateForCurrency(
 	ctx context.Context,
 	req *pb.CurrencyRequest,
 ) (*pb.ExchangeRateResponse, error) {
 
-	if _, ok := supportedCurrencies[req.FromCurrency]; !ok {
-		err := fmt.Errorf("unsupported from currency: %s", req.FromCurrency)
+	if !s.currencies.Supported(req.FromCurrency) {
+		err := fmt.Errorf("%w: from %s", ErrUnsupportedCurrency, req.FromCurrency)
 		s.log.Errorf("op: get exchange rate, err: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
-	if _, ok := supportedCurrencies[req.ToCurrency]; !ok {
-		err := fmt.Errorf("unsupported to currency: %s", req.ToCurrency)
+	if !s.currencies.Supported(req.ToCurrency) {
+		err := fmt.Errorf("%w: to %s", ErrUnsupportedCurrency, req.ToCurrency)
 		s.log.Errorf("op: get exchange rate, err: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	ratePtr, err := s.reader.Get(ctx, req.FromCurrency, req.ToCurrency)
+	asOf, err := asOfFromMetadata(ctx)
 	if err != nil {
 		s.log.Errorf("op: get exchange rate, err: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	if ratePtr == nil {
-		s.log.Warnf("op: get exchange rate, rate not found: %s -> %s", req.FromCurrency, req.ToCurrency)
-		return nil, nil
+	resolved, err := s.resolveRate(ctx, req.FromCurrency, req.ToCurrency, asOf)
+	if err != nil {
+		s.log.Errorf("op: get exchange rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	if resolved == nil {
+		err := fmt.Errorf("%w: %s -> %s", ErrRateNotFound, req.FromCurrency, req.ToCurrency)
+		if asOf != nil {
+			err = fmt.Errorf("%w as of %s", err, asOf)
+		}
+		s.log.Warnf("op: get exchange rate, err: %v", err)
+		return nil, toStatusError(err)
 	}
 
 	return &pb.ExchangeRateResponse{
 		FromCurrency: req.FromCurrency,
 		ToCurrency:   req.ToCurrency,
-		Rate:         float32(*ratePtr),
+		Rate:         float32(resolved.rate.InexactFloat64()),
 	}, nil
 }
 
@@ -91,15 +142,107 @@ func (s *ExchangeRateService) GetExchangeRates(
 	rows, err := s.reader.List(ctx)
 	if err != nil {
 		s.log.Errorf("op: list exchange rates, err: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	rates := make(map[string]float32, len(rows))
 	for _, r := range rows {
-		rates[r.ToCurrency] = float32(r.Rate)
+		rates[r.ToCurrency] = float32(r.Rate.InexactFloat64())
 	}
 
 	return &pb.ExchangeRatesResponse{
 		Rates: rates,
 	}, nil
 }
+
+// GetRate returns the exchange rate for a currency pair that was effective at the requested instant.
+// If as_of is not set, the current rate is returned.
+func (s *ExchangeRateService) GetRate(
+	ctx context.Context,
+	req *exchangev1.RateRequest,
+) (*exchangev1.RateResponse, error) {
+
+	if !s.currencies.Supported(req.FromCurrency) {
+		err := fmt.Errorf("%w: from %s", ErrUnsupportedCurrency, req.FromCurrency)
+		s.log.Errorf("op: get rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	if !s.currencies.Supported(req.ToCurrency) {
+		err := fmt.Errorf("%w: to %s", ErrUnsupportedCurrency, req.ToCurrency)
+		s.log.Errorf("op: get rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	asOf := time.Now().UTC()
+	var at *time.Time
+	if req.AsOf != nil {
+		if err := req.AsOf.CheckValid(); err != nil {
+			err = fmt.Errorf("%w: as_of: %w", ErrInvalidArgument, err)
+			s.log.Errorf("op: get rate, err: %v", err)
+			return nil, toStatusError(err)
+		}
+		asOf = req.AsOf.AsTime()
+		at = &asOf
+	}
+
+	resolved, err := s.resolveRate(ctx, req.FromCurrency, req.ToCurrency, at)
+	if err != nil {
+		s.log.Errorf("op: get rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	if resolved == nil {
+		err := fmt.Errorf("%w: %s -> %s as of %s", ErrRateNotFound, req.FromCurrency, req.ToCurrency, asOf)
+		s.log.Warnf("op: get rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	updatedAt, err := s.legUpdateTimes(ctx, resolved.legs, at)
+	if err != nil {
+		s.log.Errorf("op: get rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	resp := &exchangev1.RateResponse{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Rate:         resolved.rate.InexactFloat64(),
+		AsOf:         timestamppb.New(asOf),
+		RateDecimal:  toDecimalPB(resolved.rate),
+		Derived:      resolved.derived,
+	}
+	if resolved.override != nil {
+		resp.Override = true
+		resp.OverrideExpiresAt = timestamppb.New(resolved.override.ExpiresAt)
+	}
+	for _, leg := range resolved.legs {
+		pbLeg := &exchangev1.RateLeg{
+			FromCurrency: leg.fromCurrency,
+			ToCurrency:   leg.toCurrency,
+			Rate:         toDecimalPB(leg.rate),
+			Override:     leg.override,
+		}
+		if t, ok := updatedAt[pairKey(leg.fromCurrency, leg.toCurrency)]; ok {
+			pbLeg.UpdatedAt = timestamppb.New(t)
+		}
+		resp.Legs = append(resp.Legs, pbLeg)
+	}
+
+	return resp, nil
+}
+
+// asOfFromMetadata returns the instant of the as-of request metadata, nil if it is not set.
+func asOfFromMetadata(ctx context.Context) (*time.Time, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(AsOfMetadataKey)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339Nano, values[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s metadata: %w", ErrInvalidArgument, AsOfMetadataKey, err)
+	}
+	asOf = asOf.UTC()
+	return &asOf, nil
+}
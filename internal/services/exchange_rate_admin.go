@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExchangeRateWriter is an interface for writing currency exchange rates.
+type ExchangeRateWriter interface {
+	Save(ctx context.Context, fromCurrency, toCurrency string, rate decimal.Decimal) (*models.ExchangeRateDB, error)
+	SaveAll(ctx context.Context, rates []models.ExchangeRateDB) ([]models.ExchangeRateDB, error)
+	Delete(ctx context.Context, fromCurrency, toCurrency string) (bool, error)
+}
+
+// ExchangeRateAdminService implements the gRPC admin server for managing currency exchange rates.
+type ExchangeRateAdminService struct {
+	exchangev1.UnimplementedAdminServiceServer
+	writer         ExchangeRateWriter
+	currencies     *CurrencyRegistry
+	overrideWriter RateOverrideWriter
+	overrides      *RateOverrides
+	log            *zap.SugaredLogger
+}
+
+// ExchangeRateAdminServiceOption configures optional behaviour of ExchangeRateAdminService.
+type ExchangeRateAdminServiceOption func(*ExchangeRateAdminService)
+
+// NewExchangeRateAdminService creates a new instance of ExchangeRateAdminService.
+func NewExchangeRateAdminService(
+	log *zap.SugaredLogger,
+	writer ExchangeRateWriter,
+	currencies *CurrencyRegistry,
+	opts ...ExchangeRateAdminServiceOption,
+) *ExchangeRateAdminService {
+	s := &ExchangeRateAdminService{
+		writer:     writer,
+		currencies: currencies,
+		log:        log,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// UpsertRate creates or updates the exchange rate for a currency pair.
+func (s *ExchangeRateAdminService) UpsertRate(
+	ctx context.Context,
+	req *exchangev1.UpsertRateRequest,
+) (*exchangev1.ExchangeRate, error) {
+
+	rate, err := parseRateInput(s.currencies, req)
+	if err != nil {
+		s.log.Errorf("op: upsert rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	saved, err := s.writer.Save(ctx, req.FromCurrency, req.ToCurrency, rate)
+	if err != nil {
+		s.log.Errorf("op: upsert rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	return toExchangeRatePB(*saved), nil
+}
+
+// UpsertRates creates or updates several exchange rates in a single transaction.
+func (s *ExchangeRateAdminService) UpsertRates(
+	ctx context.Context,
+	req *exchangev1.UpsertRatesRequest,
+) (*exchangev1.UpsertRatesResponse, error) {
+
+	rates := make([]models.ExchangeRateDB, 0, len(req.Rates))
+	for i, r := range req.Rates {
+		rate, err := parseRateInput(s.currencies, r)
+		if err != nil {
+			err = fmt.Errorf("rates[%d]: %w", i, err)
+			s.log.Errorf("op: upsert rates, err: %v", err)
+			return nil, toStatusError(err)
+		}
+		rates = append(rates, models.ExchangeRateDB{
+			FromCurrency: r.FromCurrency,
+			ToCurrency:   r.ToCurrency,
+			Rate:         rate,
+		})
+	}
+
+	saved, err := s.writer.SaveAll(ctx, rates)
+	if err != nil {
+		s.log.Errorf("op: upsert rates, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	resp := &exchangev1.UpsertRatesResponse{
+		Rates: make([]*exchangev1.ExchangeRate, 0, len(saved)),
+	}
+	for _, r := range saved {
+		resp.Rates = append(resp.Rates, toExchangeRatePB(r))
+	}
+
+	return resp, nil
+}
+
+// DeleteRate removes the exchange rate for a currency pair.
+func (s *ExchangeRateAdminService) DeleteRate(
+	ctx context.Context,
+	req *exchangev1.DeleteRateRequest,
+) (*exchangev1.DeleteRateResponse, error) {
+
+	if err := s.currencies.validatePair(req.FromCurrency, req.ToCurrency); err != nil {
+		s.log.Errorf("op: delete rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	deleted, err := s.writer.Delete(ctx, req.FromCurrency, req.ToCurrency)
+	if err != nil {
+		s.log.Errorf("op: delete rate, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	if !deleted {
+		s.log.Warnf("op: delete rate, rate not found: %s -> %s", req.FromCurrency, req.ToCurrency)
+	}
+
+	return &exchangev1.DeleteRateResponse{
+		Deleted: deleted,
+	}, nil
+}
+
+// parseRateInput validates the currency pair of an upsert request and returns its rate
+// as an exact decimal. rate_decimal takes precedence over the deprecated double rate.
+func parseRateInput(currencies *CurrencyRegistry, req *exchangev1.UpsertRateRequest) (decimal.Decimal, error) {
+	if err := currencies.validatePair(req.FromCurrency, req.ToCurrency); err != nil {
+		return decimal.Zero, err
+	}
+
+	rate := decimal.NewFromFloat(req.Rate)
+	if req.RateDecimal != nil {
+		var err error
+		if rate, err = fromDecimalPB(req.RateDecimal); err != nil {
+			return decimal.Zero, fmt.Errorf("%w: rate_decimal: %w", ErrInvalidArgument, err)
+		}
+	}
+
+	if err := validateStoredRate(rate); err != nil {
+		return decimal.Zero, err
+	}
+	return rate, nil
+}
+
+// toExchangeRatePB converts a DB exchange rate record to its protobuf representation.
+func toExchangeRatePB(r models.ExchangeRateDB) *exchangev1.ExchangeRate {
+	return &exchangev1.ExchangeRate{
+		ExchangeRateId: r.ExchangeRateID.String(),
+		FromCurrency:   r.FromCurrency,
+		ToCurrency:     r.ToCurrency,
+		Rate:           r.Rate.InexactFloat64(),
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+		UpdatedAt:      timestamppb.New(r.UpdatedAt),
+		RateDecimal:    toDecimalPB(r.Rate),
+	}
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /home/sergey/Github/gw-exchanger/internal/services/exchange_rate_admin.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/sbilibin2017/gw-exchanger/internal/models"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockExchangeRateWriter is a mock of ExchangeRateWriter interface.
+type MockExchangeRateWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateWriterMockRecorder
+}
+
+// MockExchangeRateWriterMockRecorder is the mock recorder for MockExchangeRateWriter.
+type MockExchangeRateWriterMockRecorder struct {
+	mock *MockExchangeRateWriter
+}
+
+// NewMockExchangeRateWriter creates a new mock instance.
+func NewMockExchangeRateWriter(ctrl *gomock.Controller) *MockExchangeRateWriter {
+	mock := &MockExchangeRateWriter{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateWriter) EXPECT() *MockExchangeRateWriterMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockExchangeRateWriter) Delete(ctx context.Context, fromCurrency, toCurrency string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, fromCurrency, toCurrency)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExchangeRateWriterMockRecorder) Delete(ctx, fromCurrency, toCurrency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExchangeRateWriter)(nil).Delete), ctx, fromCurrency, toCurrency)
+}
+
+// Save mocks base method.
+func (m *MockExchangeRateWriter) Save(ctx context.Context, fromCurrency, toCurrency string, rate decimal.Decimal) (*models.ExchangeRateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, fromCurrency, toCurrency, rate)
+	ret0, _ := ret[0].(*models.ExchangeRateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockExchangeRateWriterMockRecorder) Save(ctx, fromCurrency, toCurrency, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockExchangeRateWriter)(nil).Save), ctx, fromCurrency, toCurrency, rate)
+}
+
+// SaveAll mocks base method.
+func (m *MockExchangeRateWriter) SaveAll(ctx context.Context, rates []models.ExchangeRateDB) ([]models.ExchangeRateDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAll", ctx, rates)
+	ret0, _ := ret[0].([]models.ExchangeRateDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAll indicates an expected call of SaveAll.
+func (mr *MockExchangeRateWriterMockRecorder) SaveAll(ctx, rates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAll", reflect.TypeOf((*MockExchangeRateWriter)(nil).SaveAll), ctx, rates)
+}
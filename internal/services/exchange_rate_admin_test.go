@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUpsertRate(t *testing.T) {
+	now := time.Now()
+	id := uuid.New()
+
+	testCases := []struct {
+		name         string
+		req          *exchangev1.UpsertRateRequest
+		mockSetup    func(m *MockExchangeRateWriter)
+		expectError  bool
+		expectedRate string
+	}{
+		{
+			name: "exact decimal rate saved",
+			req: &exchangev1.UpsertRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				RateDecimal:  &exchangev1.Decimal{Value: "92.123456"},
+			},
+			mockSetup: func(m *MockExchangeRateWriter) {
+				rate := decimal.RequireFromString("92.123456")
+				m.EXPECT().
+					Save(gomock.Any(), "USD", "RUB", rate).
+					Return(&models.ExchangeRateDB{ExchangeRateID: id, FromCurrency: "USD", ToCurrency: "RUB", Rate: rate, CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			expectError:  false,
+			expectedRate: "92.123456",
+		},
+		{
+			name: "double rate saved when decimal is not set",
+			req:  &exchangev1.UpsertRateRequest{FromCurrency: "USD", ToCurrency: "RUB", Rate: 92.5},
+			mockSetup: func(m *MockExchangeRateWriter) {
+				rate := decimal.RequireFromString("92.5")
+				m.EXPECT().
+					Save(gomock.Any(), "USD", "RUB", rate).
+					Return(&models.ExchangeRateDB{ExchangeRateID: id, FromCurrency: "USD", ToCurrency: "RUB", Rate: rate, CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			expectError:  false,
+			expectedRate: "92.5",
+		},
+		{
+			name: "writer returns error",
+			req:  &exchangev1.UpsertRateRequest{FromCurrency: "USD", ToCurrency: "RUB", Rate: 92.5},
+			mockSetup: func(m *MockExchangeRateWriter) {
+				m.EXPECT().
+					Save(gomock.Any(), "USD", "RUB", decimal.RequireFromString("92.5")).
+					Return(nil, errors.New("db error"))
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid decimal rate",
+			req: &exchangev1.UpsertRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				RateDecimal:  &exchangev1.Decimal{Value: "92,5"},
+			},
+			mockSetup:   func(m *MockExchangeRateWriter) {},
+			expectError: true,
+		},
+		{
+			name:        "unsupported currency",
+			req:         &exchangev1.UpsertRateRequest{FromCurrency: "GBP", ToCurrency: "RUB", Rate: 92.5},
+			mockSetup:   func(m *MockExchangeRateWriter) {},
+			expectError: true,
+		},
+		{
+			name:        "same currencies",
+			req:         &exchangev1.UpsertRateRequest{FromCurrency: "USD", ToCurrency: "USD", Rate: 1},
+			mockSetup:   func(m *MockExchangeRateWriter) {},
+			expectError: true,
+		},
+		{
+			name:        "non-positive rate",
+			req:         &exchangev1.UpsertRateRequest{FromCurrency: "USD", ToCurrency: "RUB", Rate: 0},
+			mockSetup:   func(m *MockExchangeRateWriter) {},
+			expectError: true,
+		},
+		{
+			name: "rate with more than six decimal places",
+			req: &exchangev1.UpsertRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				RateDecimal:  &exchangev1.Decimal{Value: "92.1234567"},
+			},
+			mockSetup:   func(m *MockExchangeRateWriter) {},
+			expectError: true,
+		},
+		{
+			name: "rate too large for storage",
+			req: &exchangev1.UpsertRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				RateDecimal:  &exchangev1.Decimal{Value: "1000000000000"},
+			},
+			mockSetup:   func(m *MockExchangeRateWriter) {},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWriter := NewMockExchangeRateWriter(ctrl)
+			tc.mockSetup(mockWriter)
+			svc := NewExchangeRateAdminService(zap.NewNop().Sugar(), mockWriter, newTestCurrencyRegistry(t))
+
+			resp, err := svc.UpsertRate(context.Background(), tc.req)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				assert.Equal(t, id.String(), resp.ExchangeRateId)
+				assert.Equal(t, tc.expectedRate, resp.RateDecimal.Value)
+			}
+		})
+	}
+}
+
+func TestUpsertRates(t *testing.T) {
+	testCases := []struct {
+		name        string
+		req         *exchangev1.UpsertRatesRequest
+		mockSetup   func(m *MockExchangeRateWriter)
+		expectError bool
+		expectedLen int
+	}{
+		{
+			name: "all rates saved",
+			req: &exchangev1.UpsertRatesRequest{Rates: []*exchangev1.UpsertRateRequest{
+				{FromCurrency: "USD", ToCurrency: "RUB", RateDecimal: &exchangev1.Decimal{Value: "92.123456"}},
+				{FromCurrency: "EUR", ToCurrency: "RUB", RateDecimal: &exchangev1.Decimal{Units: 100, Nanos: 100000000}},
+			}},
+			mockSetup: func(m *MockExchangeRateWriter) {
+				usdRub := decimal.RequireFromString("92.123456")
+				eurRub := decimal.RequireFromString("100.1")
+				m.EXPECT().
+					SaveAll(gomock.Any(), gomock.Len(2)).
+					DoAndReturn(func(_ context.Context, rates []models.ExchangeRateDB) ([]models.ExchangeRateDB, error) {
+						assert.True(t, usdRub.Equal(rates[0].Rate))
+						assert.True(t, eurRub.Equal(rates[1].Rate))
+						return []models.ExchangeRateDB{
+							{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: usdRub},
+							{ExchangeRateID: uuid.New(), FromCurrency: "EUR", ToCurrency: "RUB", Rate: eurRub},
+						}, nil
+					})
+			},
+			expectError: false,
+			expectedLen: 2,
+		},
+		{
+			name: "invalid rate rejects the whole batch",
+			req: &exchangev1.UpsertRatesRequest{Rates: []*exchangev1.UpsertRateRequest{
+				{FromCurrency: "USD", ToCurrency: "RUB", Rate: 92.5},
+				{FromCurrency: "EUR", ToCurrency: "JPY", Rate: 160},
+			}},
+			mockSetup:   func(m *MockExchangeRateWriter) {},
+			expectError: true,
+		},
+		{
+			name: "writer returns error",
+			req: &exchangev1.UpsertRatesRequest{Rates: []*exchangev1.UpsertRateRequest{
+				{FromCurrency: "USD", ToCurrency: "RUB", Rate: 92.5},
+			}},
+			mockSetup: func(m *MockExchangeRateWriter) {
+				m.EXPECT().
+					SaveAll(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWriter := NewMockExchangeRateWriter(ctrl)
+			tc.mockSetup(mockWriter)
+			svc := NewExchangeRateAdminService(zap.NewNop().Sugar(), mockWriter, newTestCurrencyRegistry(t))
+
+			resp, err := svc.UpsertRates(context.Background(), tc.req)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				assert.Len(t, resp.Rates, tc.expectedLen)
+			}
+		})
+	}
+}
+
+func TestDeleteRate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		req           *exchangev1.DeleteRateRequest
+		mockSetup     func(m *MockExchangeRateWriter)
+		expectError   bool
+		expectDeleted bool
+	}{
+		{
+			name: "rate deleted",
+			req:  &exchangev1.DeleteRateRequest{FromCurrency: "USD", ToCurrency: "RUB"},
+			mockSetup: func(m *MockExchangeRateWriter) {
+				m.EXPECT().Delete(gomock.Any(), "USD", "RUB").Return(true, nil)
+			},
+			expectDeleted: true,
+		},
+		{
+			name: "rate not found",
+			req:  &exchangev1.DeleteRateRequest{FromCurrency: "USD", ToCurrency: "EUR"},
+			mockSetup: func(m *MockExchangeRateWriter) {
+				m.EXPECT().Delete(gomock.Any(), "USD", "EUR").Return(false, nil)
+			},
+			expectDeleted: false,
+		},
+		{
+			name: "writer returns error",
+			req:  &exchangev1.DeleteRateRequest{FromCurrency: "USD", ToCurrency: "RUB"},
+			mockSetup: func(m *MockExchangeRateWriter) {
+				m.EXPECT().Delete(gomock.Any(), "USD", "RUB").Return(false, errors.New("db error"))
+			},
+			expectError: true,
+		},
+		{
+			name:        "unsupported currency",
+			req:         &exchangev1.DeleteRateRequest{FromCurrency: "USD", ToCurrency: "JPY"},
+			mockSetup:   func(m *MockExchangeRateWriter) {},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWriter := NewMockExchangeRateWriter(ctrl)
+			tc.mockSetup(mockWriter)
+			svc := NewExchangeRateAdminService(zap.NewNop().Sugar(), mockWriter, newTestCurrencyRegistry(t))
+
+			resp, err := svc.DeleteRate(context.Background(), tc.req)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				assert.Equal(t, tc.expectDeleted, resp.Deleted)
+			}
+		})
+	}
+}
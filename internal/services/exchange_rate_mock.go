@@ -7,9 +7,11 @@ package services
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/sbilibin2017/gw-exchanger/internal/models"
+	decimal "github.com/shopspring/decimal"
 )
 
 // MockExchangeRateReader is a mock of ExchangeRateReader interface.
@@ -36,10 +38,10 @@ func (m *MockExchangeRateReader) EXPECT() *MockExchangeRateReaderMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockExchangeRateReader) Get(ctx context.Context, fromCurrency, toCurrency string) (*float64, error) {
+func (m *MockExchangeRateReader) Get(ctx context.Context, fromCurrency, toCurrency string) (*decimal.Decimal, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", ctx, fromCurrency, toCurrency)
-	ret0, _ := ret[0].(*float64)
+	ret0, _ := ret[0].(*decimal.Decimal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -50,6 +52,21 @@ func (mr *MockExchangeRateReaderMockRecorder) Get(ctx, fromCurrency, toCurrency
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockExchangeRateReader)(nil).Get), ctx, fromCurrency, toCurrency)
 }
 
+// GetAt mocks base method.
+func (m *MockExchangeRateReader) GetAt(ctx context.Context, fromCurrency, toCurrency string, asOf time.Time) (*decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAt", ctx, fromCurrency, toCurrency, asOf)
+	ret0, _ := ret[0].(*decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAt indicates an expected call of GetAt.
+func (mr *MockExchangeRateReaderMockRecorder) GetAt(ctx, fromCurrency, toCurrency, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAt", reflect.TypeOf((*MockExchangeRateReader)(nil).GetAt), ctx, fromCurrency, toCurrency, asOf)
+}
+
 // List mocks base method.
 func (m *MockExchangeRateReader) List(ctx context.Context) ([]models.ExchangeRateDB, error) {
 	m.ctrl.T.Helper()
@@ -4,27 +4,40 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
 	"github.com/sbilibin2017/gw-exchanger/internal/models"
 	pb "github.com/sbilibin2017/proto-exchange/exchange"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// floatPtr helper
-func floatPtr(f float64) *float64 {
-	return &f
+// decimalPtr helper
+func decimalPtr(s string) *decimal.Decimal {
+	d := decimal.RequireFromString(s)
+	return &d
 }
 
 func TestGetExchangeRateForCurrency(t *testing.T) {
+	asOf := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
 	testCases := []struct {
 		name          string
 		fromCurrency  string
 		toCurrency    string
+		asOf          string // as-of request metadata, not sent if empty
 		mockSetup     func(t *testing.T) (*ExchangeRateService, *gomock.Controller)
 		expectError   bool
 		expectNilResp bool
+		expectedCode  codes.Code
 		expectedRate  float32
 	}{
 		{
@@ -36,8 +49,8 @@ func TestGetExchangeRateForCurrency(t *testing.T) {
 				mockReader := NewMockExchangeRateReader(ctrl)
 				mockReader.EXPECT().
 					Get(gomock.Any(), "USD", "RUB").
-					Return(floatPtr(75.5), nil)
-				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader)
+					Return(decimalPtr("75.5"), nil)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
 				return svc, ctrl
 			},
 			expectError:   false,
@@ -54,11 +67,12 @@ func TestGetExchangeRateForCurrency(t *testing.T) {
 				mockReader.EXPECT().
 					Get(gomock.Any(), "USD", "EUR").
 					Return(nil, nil)
-				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
 				return svc, ctrl
 			},
-			expectError:   false,
+			expectError:   true,
 			expectNilResp: true,
+			expectedCode:  codes.NotFound,
 		},
 		{
 			name:         "reader returns error",
@@ -70,18 +84,65 @@ func TestGetExchangeRateForCurrency(t *testing.T) {
 				mockReader.EXPECT().
 					Get(gomock.Any(), "USD", "RUB").
 					Return(nil, errors.New("db error"))
-				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
 				return svc, ctrl
 			},
 			expectError:   true,
 			expectNilResp: true,
 		},
+		{
+			name:         "historical rate as of metadata instant",
+			fromCurrency: "USD",
+			toCurrency:   "RUB",
+			asOf:         "2025-01-15T15:00:00+03:00",
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				ctrl := gomock.NewController(t)
+				mockReader := NewMockExchangeRateReader(ctrl)
+				mockReader.EXPECT().
+					GetAt(gomock.Any(), "USD", "RUB", asOf).
+					Return(decimalPtr("90.25"), nil)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+				return svc, ctrl
+			},
+			expectedRate: 90.25,
+		},
+		{
+			name:         "historical rate not found",
+			fromCurrency: "USD",
+			toCurrency:   "RUB",
+			asOf:         "2025-01-15T12:00:00Z",
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				ctrl := gomock.NewController(t)
+				mockReader := NewMockExchangeRateReader(ctrl)
+				mockReader.EXPECT().
+					GetAt(gomock.Any(), "USD", "RUB", asOf).
+					Return(nil, nil)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+				return svc, ctrl
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:         "invalid as-of metadata",
+			fromCurrency: "USD",
+			toCurrency:   "RUB",
+			asOf:         "yesterday",
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
+				return svc, nil
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.InvalidArgument,
+		},
 		{
 			name:         "unsupported from currency",
 			fromCurrency: "GBP",
 			toCurrency:   "USD",
 			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
-				svc := NewExchangeRateService(zap.NewNop().Sugar(), nil)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
 				return svc, nil
 			},
 			expectError:   true,
@@ -92,7 +153,7 @@ func TestGetExchangeRateForCurrency(t *testing.T) {
 			fromCurrency: "USD",
 			toCurrency:   "JPY",
 			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
-				svc := NewExchangeRateService(zap.NewNop().Sugar(), nil)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
 				return svc, nil
 			},
 			expectError:   true,
@@ -107,13 +168,21 @@ func TestGetExchangeRateForCurrency(t *testing.T) {
 				defer ctrl.Finish()
 			}
 
-			resp, err := svc.GetExchangeRateForCurrency(context.Background(), &pb.CurrencyRequest{
+			ctx := context.Background()
+			if tc.asOf != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(AsOfMetadataKey, tc.asOf))
+			}
+
+			resp, err := svc.GetExchangeRateForCurrency(ctx, &pb.CurrencyRequest{
 				FromCurrency: tc.fromCurrency,
 				ToCurrency:   tc.toCurrency,
 			})
 
 			if tc.expectError {
 				assert.Error(t, err)
+				if tc.expectedCode != codes.OK {
+					assert.Equal(t, tc.expectedCode, status.Code(err))
+				}
 			} else {
 				assert.NoError(t, err)
 			}
@@ -143,10 +212,10 @@ func TestGetExchangeRates(t *testing.T) {
 				mockReader.EXPECT().
 					List(gomock.Any()).
 					Return([]models.ExchangeRateDB{
-						{ToCurrency: "RUB", Rate: 75.5},
-						{ToCurrency: "EUR", Rate: 0.92},
+						{ToCurrency: "RUB", Rate: decimal.RequireFromString("75.5")},
+						{ToCurrency: "EUR", Rate: decimal.RequireFromString("0.92")},
 					}, nil)
-				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
 				return svc, ctrl
 			},
 			expectError: false,
@@ -163,7 +232,7 @@ func TestGetExchangeRates(t *testing.T) {
 				mockReader.EXPECT().
 					List(gomock.Any()).
 					Return([]models.ExchangeRateDB{}, nil)
-				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
 				return svc, ctrl
 			},
 			expectError:   false,
@@ -177,7 +246,7 @@ func TestGetExchangeRates(t *testing.T) {
 				mockReader.EXPECT().
 					List(gomock.Any()).
 					Return(nil, errors.New("db error"))
-				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
 				return svc, ctrl
 			},
 			expectError:   true,
@@ -207,3 +276,498 @@ func TestGetExchangeRates(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRate(t *testing.T) {
+	asOf := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		fromCurrency  string
+		toCurrency    string
+		asOf          *timestamppb.Timestamp
+		mockSetup     func(t *testing.T) (*ExchangeRateService, *gomock.Controller)
+		expectError   bool
+		expectNilResp bool
+		expectedCode  codes.Code
+		expectedRate  string
+	}{
+		{
+			name:         "current rate when as_of is not set",
+			fromCurrency: "USD",
+			toCurrency:   "RUB",
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				ctrl := gomock.NewController(t)
+				mockReader := NewMockExchangeRateReader(ctrl)
+				mockReader.EXPECT().
+					Get(gomock.Any(), "USD", "RUB").
+					Return(decimalPtr("92.123456"), nil)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+				return svc, ctrl
+			},
+			expectError:   false,
+			expectNilResp: false,
+			expectedRate:  "92.123456",
+		},
+		{
+			name:         "historical rate as of instant",
+			fromCurrency: "USD",
+			toCurrency:   "RUB",
+			asOf:         timestamppb.New(asOf),
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				ctrl := gomock.NewController(t)
+				mockReader := NewMockExchangeRateReader(ctrl)
+				mockReader.EXPECT().
+					GetAt(gomock.Any(), "USD", "RUB", asOf).
+					Return(decimalPtr("101.5"), nil)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+				return svc, ctrl
+			},
+			expectError:   false,
+			expectNilResp: false,
+			expectedRate:  "101.5",
+		},
+		{
+			name:         "historical rate not found",
+			fromCurrency: "USD",
+			toCurrency:   "EUR",
+			asOf:         timestamppb.New(asOf),
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				ctrl := gomock.NewController(t)
+				mockReader := NewMockExchangeRateReader(ctrl)
+				mockReader.EXPECT().
+					GetAt(gomock.Any(), "USD", "EUR", asOf).
+					Return(nil, nil)
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+				return svc, ctrl
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:         "reader returns error",
+			fromCurrency: "USD",
+			toCurrency:   "RUB",
+			asOf:         timestamppb.New(asOf),
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				ctrl := gomock.NewController(t)
+				mockReader := NewMockExchangeRateReader(ctrl)
+				mockReader.EXPECT().
+					GetAt(gomock.Any(), "USD", "RUB", asOf).
+					Return(nil, errors.New("db error"))
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+				return svc, ctrl
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name:         "invalid as_of",
+			fromCurrency: "USD",
+			toCurrency:   "RUB",
+			asOf:         &timestamppb.Timestamp{Nanos: -1},
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
+				return svc, nil
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name:         "unsupported from currency",
+			fromCurrency: "GBP",
+			toCurrency:   "USD",
+			mockSetup: func(t *testing.T) (*ExchangeRateService, *gomock.Controller) {
+				svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
+				return svc, nil
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, ctrl := tc.mockSetup(t)
+			if ctrl != nil {
+				defer ctrl.Finish()
+			}
+
+			resp, err := svc.GetRate(context.Background(), &exchangev1.RateRequest{
+				FromCurrency: tc.fromCurrency,
+				ToCurrency:   tc.toCurrency,
+				AsOf:         tc.asOf,
+			})
+
+			if tc.expectError {
+				assert.Error(t, err)
+				if tc.expectedCode != codes.OK {
+					assert.Equal(t, tc.expectedCode, status.Code(err))
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tc.expectNilResp {
+				assert.Nil(t, resp)
+			} else {
+				assert.NotNil(t, resp)
+				assert.Equal(t, tc.expectedRate, resp.RateDecimal.Value)
+				if tc.asOf != nil {
+					assert.Equal(t, tc.asOf.AsTime(), resp.AsOf.AsTime())
+				}
+			}
+		})
+	}
+}
+
+func TestGetRateTriangulation(t *testing.T) {
+	legUpdatedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		fromCurrency  string
+		toCurrency    string
+		opts          []ExchangeRateServiceOption
+		mockSetup     func(m *MockExchangeRateReader)
+		expectError   bool
+		expectNilResp bool
+		expectedCode  codes.Code
+		expectedRate  string
+		expectDerived bool
+		expectedLegs  []string // "FROM->TO@updated_at" of every leg, updated_at in RFC 3339
+	}{
+		{
+			name:         "stored rate is preferred over derived",
+			fromCurrency: "EUR",
+			toCurrency:   "RUB",
+			opts:         []ExchangeRateServiceOption{WithPivotCurrency("USD")},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(decimalPtr("100.1"), nil)
+			},
+			expectedRate:  "100.1",
+			expectDerived: false,
+		},
+		{
+			name:         "missing pair derived through pivot",
+			fromCurrency: "EUR",
+			toCurrency:   "RUB",
+			opts:         []ExchangeRateServiceOption{WithPivotCurrency("USD")},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "EUR", "USD").Return(decimalPtr("1.08"), nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("92.5"), nil)
+				m.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+					{FromCurrency: "EUR", ToCurrency: "USD", Rate: decimal.RequireFromString("1.08"), UpdatedAt: legUpdatedAt},
+					{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5"), UpdatedAt: legUpdatedAt.Add(time.Hour)},
+					{FromCurrency: "USD", ToCurrency: "CNY", Rate: decimal.RequireFromString("7.2"), UpdatedAt: legUpdatedAt},
+				}, nil)
+			},
+			expectedRate:  "99.9",
+			expectDerived: true,
+			expectedLegs:  []string{"EUR->USD@2025-01-15T12:00:00Z", "USD->RUB@2025-01-15T13:00:00Z"},
+		},
+		{
+			name:         "inverse leg reported in stored direction",
+			fromCurrency: "EUR",
+			toCurrency:   "RUB",
+			opts: []ExchangeRateServiceOption{
+				WithPivotCurrency("USD"),
+				WithInversePolicy(InversePolicyPreferStored, decimal.Zero),
+			},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "RUB", "EUR").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "EUR", "USD").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "EUR").Return(decimalPtr("0.8"), nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("92.5"), nil)
+				m.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+					{FromCurrency: "USD", ToCurrency: "EUR", Rate: decimal.RequireFromString("0.8"), UpdatedAt: legUpdatedAt},
+					{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5"), UpdatedAt: legUpdatedAt},
+				}, nil)
+			},
+			expectedRate:  "115.625",
+			expectDerived: true,
+			expectedLegs:  []string{"USD->EUR@2025-01-15T12:00:00Z", "USD->RUB@2025-01-15T12:00:00Z"},
+		},
+		{
+			name:         "missing leg",
+			fromCurrency: "EUR",
+			toCurrency:   "RUB",
+			opts:         []ExchangeRateServiceOption{WithPivotCurrency("USD")},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "EUR", "USD").Return(decimalPtr("1.08"), nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(nil, nil)
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:         "leg lookup error",
+			fromCurrency: "EUR",
+			toCurrency:   "RUB",
+			opts:         []ExchangeRateServiceOption{WithPivotCurrency("USD")},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "EUR", "USD").Return(nil, errors.New("db error"))
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name:         "pivot is one of the currencies",
+			fromCurrency: "USD",
+			toCurrency:   "RUB",
+			opts:         []ExchangeRateServiceOption{WithPivotCurrency("USD")},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(nil, nil)
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:         "pivot disabled",
+			fromCurrency: "EUR",
+			toCurrency:   "RUB",
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(nil, nil)
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReader := NewMockExchangeRateReader(ctrl)
+			tc.mockSetup(mockReader)
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t), tc.opts...)
+
+			resp, err := svc.GetRate(context.Background(), &exchangev1.RateRequest{
+				FromCurrency: tc.fromCurrency,
+				ToCurrency:   tc.toCurrency,
+			})
+
+			if tc.expectError {
+				assert.Error(t, err)
+				if tc.expectedCode != codes.OK {
+					assert.Equal(t, tc.expectedCode, status.Code(err))
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tc.expectNilResp {
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NotNil(t, resp)
+			assert.True(t, decimal.RequireFromString(tc.expectedRate).Equal(decimal.RequireFromString(resp.RateDecimal.Value)))
+			assert.Equal(t, tc.expectDerived, resp.Derived)
+			legs := make([]string, 0, len(resp.Legs))
+			for _, leg := range resp.Legs {
+				require.NotNil(t, leg.UpdatedAt)
+				legs = append(legs, leg.FromCurrency+"->"+leg.ToCurrency+"@"+leg.UpdatedAt.AsTime().Format(time.RFC3339))
+			}
+			assert.ElementsMatch(t, tc.expectedLegs, legs)
+		})
+	}
+}
+
+func TestTriangulationHistoricalAndLegacy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	asOf := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	mockReader := NewMockExchangeRateReader(ctrl)
+	mockReader.EXPECT().GetAt(gomock.Any(), "EUR", "RUB", asOf).Return(nil, nil)
+	mockReader.EXPECT().GetAt(gomock.Any(), "EUR", "USD", asOf).Return(decimalPtr("1.1"), nil)
+	mockReader.EXPECT().GetAt(gomock.Any(), "USD", "RUB", asOf).Return(decimalPtr("90"), nil)
+	svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t), WithPivotCurrency("USD"))
+
+	resp, err := svc.GetRate(context.Background(), &exchangev1.RateRequest{
+		FromCurrency: "EUR",
+		ToCurrency:   "RUB",
+		AsOf:         timestamppb.New(asOf),
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, "99", resp.RateDecimal.Value)
+	assert.True(t, resp.Derived)
+	require.Len(t, resp.Legs, 2)
+	for _, leg := range resp.Legs {
+		assert.Nil(t, leg.UpdatedAt, "update times of past rates are not stored")
+	}
+
+	mockReader.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(nil, nil)
+	mockReader.EXPECT().Get(gomock.Any(), "EUR", "USD").Return(decimalPtr("1.1"), nil)
+	mockReader.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("90"), nil)
+
+	legacy, err := svc.GetExchangeRateForCurrency(context.Background(), &pb.CurrencyRequest{
+		FromCurrency: "EUR",
+		ToCurrency:   "RUB",
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, legacy)
+	assert.Equal(t, float32(99), legacy.Rate)
+}
+
+func TestGetRateInversePolicy(t *testing.T) {
+	tolerance := decimal.RequireFromString("0.001")
+
+	testCases := []struct {
+		name          string
+		policy        InversePolicy
+		mockSetup     func(m *MockExchangeRateReader)
+		expectError   bool
+		expectNilResp bool
+		expectedCode  codes.Code
+		expectedRate  string
+		expectDerived bool
+	}{
+		{
+			name:   "prefer stored uses stored rate",
+			policy: InversePolicyPreferStored,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(decimalPtr("0.0108"), nil)
+			},
+			expectedRate:  "0.0108",
+			expectDerived: false,
+		},
+		{
+			name:   "prefer stored derives missing rate",
+			policy: InversePolicyPreferStored,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("80"), nil)
+				m.EXPECT().List(gomock.Any()).Return(nil, nil)
+			},
+			expectedRate:  "0.0125",
+			expectDerived: true,
+		},
+		{
+			name:   "prefer derived uses inverse of opposite direction",
+			policy: InversePolicyPreferDerived,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("80"), nil)
+				m.EXPECT().List(gomock.Any()).Return(nil, nil)
+			},
+			expectedRate:  "0.0125",
+			expectDerived: true,
+		},
+		{
+			name:   "prefer derived falls back to stored rate",
+			policy: InversePolicyPreferDerived,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(decimalPtr("0.0108"), nil)
+			},
+			expectedRate:  "0.0108",
+			expectDerived: false,
+		},
+		{
+			name:   "strict accepts symmetric pair",
+			policy: InversePolicyStrict,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(decimalPtr("0.0125"), nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("80.01"), nil)
+			},
+			expectedRate:  "0.0125",
+			expectDerived: false,
+		},
+		{
+			name:   "strict rejects asymmetric pair",
+			policy: InversePolicyStrict,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(decimalPtr("0.0125"), nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("92.5"), nil)
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+		{
+			name:   "strict derives missing rate",
+			policy: InversePolicyStrict,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(decimalPtr("80"), nil)
+				m.EXPECT().List(gomock.Any()).Return(nil, nil)
+			},
+			expectedRate:  "0.0125",
+			expectDerived: true,
+		},
+		{
+			name:   "disabled policy does not derive",
+			policy: InversePolicyNone,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(nil, nil)
+			},
+			expectError:   true,
+			expectNilResp: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:   "opposite lookup error",
+			policy: InversePolicyPreferStored,
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(nil, nil)
+				m.EXPECT().Get(gomock.Any(), "USD", "RUB").Return(nil, errors.New("db error"))
+			},
+			expectError:   true,
+			expectNilResp: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReader := NewMockExchangeRateReader(ctrl)
+			tc.mockSetup(mockReader)
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t), WithInversePolicy(tc.policy, tolerance))
+
+			resp, err := svc.GetRate(context.Background(), &exchangev1.RateRequest{
+				FromCurrency: "RUB",
+				ToCurrency:   "USD",
+			})
+
+			if tc.expectError {
+				assert.Error(t, err)
+				if tc.expectedCode != codes.OK {
+					assert.Equal(t, tc.expectedCode, status.Code(err))
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tc.expectNilResp {
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NotNil(t, resp)
+			assert.True(t, decimal.RequireFromString(tc.expectedRate).Equal(decimal.RequireFromString(resp.RateDecimal.Value)), "got %s", resp.RateDecimal.Value)
+			assert.Equal(t, tc.expectDerived, resp.Derived)
+		})
+	}
+}
+
+func TestParseInversePolicy(t *testing.T) {
+	for _, name := range []string{"", "prefer_stored", "prefer_derived", "strict"} {
+		policy, err := ParseInversePolicy(name)
+		assert.NoError(t, err)
+		assert.Equal(t, InversePolicy(name), policy)
+	}
+
+	_, err := ParseInversePolicy("always")
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ErrRatesStale is reported by HealthProbe when exchange rates were neither updated nor fetched
+// from the rate providers within the allowed age.
+var ErrRatesStale = errors.New("rates are stale")
+
+// RatesFreshnessService is the health service name whose status reports whether exchange rates
+// are fresh. Stale rates are reported only there, so that they do not take a server that can
+// still serve the stored rates out of rotation.
+const RatesFreshnessService = "gw-exchanger.rates.freshness"
+
+// DBPinger is an interface for checking the database connection.
+type DBPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// RateFreshnessReader is an interface for reading the time of the latest exchange rate update.
+type RateFreshnessReader interface {
+	LastUpdatedAt(ctx context.Context) (*time.Time, error)
+}
+
+// HealthProbe drives the serving status of the standard gRPC health service.
+// It periodically pings the database and reports SERVING for the overall server and
+// the given services only while the database is reachable. If enabled, it also checks
+// that exchange rates are fresh and reports the result as RatesFreshnessService.
+type HealthProbe struct {
+	server   *health.Server
+	services []string // services whose status is set besides the overall one
+	db       DBPinger
+	rates    RateFreshnessReader
+	maxAge   time.Duration
+	interval time.Duration
+	timeout  time.Duration
+	now      func() time.Time
+	log      *zap.SugaredLogger
+}
+
+// HealthProbeOption configures optional checks of HealthProbe.
+type HealthProbeOption func(*HealthProbe)
+
+// WithRateFreshness makes the probe report RatesFreshnessService as NOT_SERVING when the latest
+// exchange rate update is older than maxAge or no rates are stored. Zero maxAge disables the check.
+func WithRateFreshness(rates RateFreshnessReader, maxAge time.Duration) HealthProbeOption {
+	return func(p *HealthProbe) {
+		p.rates = rates
+		p.maxAge = maxAge
+	}
+}
+
+// NewHealthProbe creates a probe updating the status of the overall server and of the given
+// services on server. interval is the period of the checks, timeout bounds a single check;
+// both must be positive.
+func NewHealthProbe(
+	log *zap.SugaredLogger,
+	server *health.Server,
+	services []string,
+	db DBPinger,
+	interval time.Duration,
+	timeout time.Duration,
+	opts ...HealthProbeOption,
+) (*HealthProbe, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("health probe interval must be positive, got %s", interval)
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("health probe timeout must be positive, got %s", timeout)
+	}
+
+	p := &HealthProbe{
+		server:   server,
+		services: services,
+		db:       db,
+		interval: interval,
+		timeout:  timeout,
+		now:      time.Now,
+		log:      log,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// Check pings the database and returns the failure that makes the server NOT_SERVING.
+func (p *HealthProbe) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	if err := p.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("database ping: %w", err)
+	}
+	return nil
+}
+
+// CheckFreshness checks that the latest exchange rate update is not older than the allowed age.
+// It returns nil if the check is disabled.
+func (p *HealthProbe) CheckFreshness(ctx context.Context) error {
+	if !p.freshnessEnabled() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	updatedAt, err := p.rates.LastUpdatedAt(ctx)
+	if err != nil {
+		return fmt.Errorf("rates freshness: %w", err)
+	}
+	if updatedAt == nil {
+		return fmt.Errorf("%w: no rates stored", ErrRatesStale)
+	}
+	if age := p.now().Sub(*updatedAt); age > p.maxAge {
+		return fmt.Errorf("%w: last update %s ago, at most %s allowed", ErrRatesStale, age.Truncate(time.Second), p.maxAge)
+	}
+	return nil
+}
+
+// Run checks the health right away and then every interval until ctx is done,
+// updating the serving status and the rates freshness status after every check.
+func (p *HealthProbe) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	var last, lastFreshness error
+	first := true
+	for {
+		err := p.Check(ctx)
+		freshnessErr := p.CheckFreshness(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch {
+		case err != nil && (first || last == nil):
+			p.log.Warnf("op: health probe, status: NOT_SERVING, err: %v", err)
+		case err == nil && (first || last != nil):
+			p.log.Infof("op: health probe, status: SERVING")
+		}
+		p.setStatus(err)
+
+		if p.freshnessEnabled() {
+			switch {
+			case freshnessErr != nil && (first || lastFreshness == nil):
+				p.log.Warnf("op: health probe, service: %s, status: NOT_SERVING, err: %v", RatesFreshnessService, freshnessErr)
+			case freshnessErr == nil && (first || lastFreshness != nil):
+				p.log.Infof("op: health probe, service: %s, status: SERVING", RatesFreshnessService)
+			}
+			p.server.SetServingStatus(RatesFreshnessService, servingStatus(freshnessErr))
+		}
+		last, lastFreshness, first = err, freshnessErr, false
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// freshnessEnabled reports whether the rates freshness check is enabled.
+func (p *HealthProbe) freshnessEnabled() bool {
+	return p.rates != nil && p.maxAge > 0
+}
+
+// setStatus sets the status of the overall server and of every service.
+func (p *HealthProbe) setStatus(err error) {
+	status := servingStatus(err)
+	p.server.SetServingStatus("", status)
+	for _, service := range p.services {
+		p.server.SetServingStatus(service, status)
+	}
+}
+
+// servingStatus returns the health status for the result of a check.
+func servingStatus(err error) healthpb.HealthCheckResponse_ServingStatus {
+	if err != nil {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
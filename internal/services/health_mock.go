@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /home/sergey/Github/gw-exchanger/internal/services/health.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDBPinger is a mock of DBPinger interface.
+type MockDBPinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBPingerMockRecorder
+}
+
+// MockDBPingerMockRecorder is the mock recorder for MockDBPinger.
+type MockDBPingerMockRecorder struct {
+	mock *MockDBPinger
+}
+
+// NewMockDBPinger creates a new mock instance.
+func NewMockDBPinger(ctrl *gomock.Controller) *MockDBPinger {
+	mock := &MockDBPinger{ctrl: ctrl}
+	mock.recorder = &MockDBPingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDBPinger) EXPECT() *MockDBPingerMockRecorder {
+	return m.recorder
+}
+
+// PingContext mocks base method.
+func (m *MockDBPinger) PingContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingContext indicates an expected call of PingContext.
+func (mr *MockDBPingerMockRecorder) PingContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingContext", reflect.TypeOf((*MockDBPinger)(nil).PingContext), ctx)
+}
+
+// MockRateFreshnessReader is a mock of RateFreshnessReader interface.
+type MockRateFreshnessReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateFreshnessReaderMockRecorder
+}
+
+// MockRateFreshnessReaderMockRecorder is the mock recorder for MockRateFreshnessReader.
+type MockRateFreshnessReaderMockRecorder struct {
+	mock *MockRateFreshnessReader
+}
+
+// NewMockRateFreshnessReader creates a new mock instance.
+func NewMockRateFreshnessReader(ctrl *gomock.Controller) *MockRateFreshnessReader {
+	mock := &MockRateFreshnessReader{ctrl: ctrl}
+	mock.recorder = &MockRateFreshnessReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateFreshnessReader) EXPECT() *MockRateFreshnessReaderMockRecorder {
+	return m.recorder
+}
+
+// LastUpdatedAt mocks base method.
+func (m *MockRateFreshnessReader) LastUpdatedAt(ctx context.Context) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastUpdatedAt", ctx)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastUpdatedAt indicates an expected call of LastUpdatedAt.
+func (mr *MockRateFreshnessReaderMockRecorder) LastUpdatedAt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUpdatedAt", reflect.TypeOf((*MockRateFreshnessReader)(nil).LastUpdatedAt), ctx)
+}
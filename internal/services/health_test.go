@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestNewHealthProbeRejectsNonPositivePeriods(t *testing.T) {
+	testCases := []struct {
+		name     string
+		interval time.Duration
+		timeout  time.Duration
+	}{
+		{name: "zero interval", interval: 0, timeout: time.Second},
+		{name: "negative interval", interval: -time.Second, timeout: time.Second},
+		{name: "zero timeout", interval: time.Second, timeout: 0},
+		{name: "negative timeout", interval: time.Second, timeout: -time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			probe, err := NewHealthProbe(zap.NewNop().Sugar(), health.NewServer(), nil, NewMockDBPinger(ctrl), tc.interval, tc.timeout)
+
+			assert.Error(t, err)
+			assert.Nil(t, probe)
+		})
+	}
+}
+
+func TestHealthProbeCheck(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pingErr     error
+		expectError bool
+	}{
+		{name: "database reachable"},
+		{name: "database unreachable", pingErr: errors.New("connection refused"), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := NewMockDBPinger(ctrl)
+			mockDB.EXPECT().PingContext(gomock.Any()).Return(tc.pingErr)
+			// Rates freshness does not take part in the serving status.
+			probe, err := NewHealthProbe(zap.NewNop().Sugar(), health.NewServer(), nil, mockDB, time.Second, time.Second,
+				WithRateFreshness(NewMockRateFreshnessReader(ctrl), time.Hour))
+			require.NoError(t, err)
+
+			err = probe.Check(context.Background())
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHealthProbeCheckFreshness(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	fresh := now.Add(-time.Minute)
+	stale := now.Add(-2 * time.Hour)
+
+	testCases := []struct {
+		name        string
+		maxAge      time.Duration
+		mockSetup   func(rates *MockRateFreshnessReader)
+		expectError bool
+		expectStale bool
+	}{
+		{
+			name:   "rates fresh",
+			maxAge: time.Hour,
+			mockSetup: func(rates *MockRateFreshnessReader) {
+				rates.EXPECT().LastUpdatedAt(gomock.Any()).Return(&fresh, nil)
+			},
+		},
+		{
+			name:      "freshness check disabled",
+			maxAge:    0,
+			mockSetup: func(rates *MockRateFreshnessReader) {},
+		},
+		{
+			name:   "rates stale",
+			maxAge: time.Hour,
+			mockSetup: func(rates *MockRateFreshnessReader) {
+				rates.EXPECT().LastUpdatedAt(gomock.Any()).Return(&stale, nil)
+			},
+			expectError: true,
+			expectStale: true,
+		},
+		{
+			name:   "no rates stored",
+			maxAge: time.Hour,
+			mockSetup: func(rates *MockRateFreshnessReader) {
+				rates.EXPECT().LastUpdatedAt(gomock.Any()).Return(nil, nil)
+			},
+			expectError: true,
+			expectStale: true,
+		},
+		{
+			name:   "freshness read error",
+			maxAge: time.Hour,
+			mockSetup: func(rates *MockRateFreshnessReader) {
+				rates.EXPECT().LastUpdatedAt(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRates := NewMockRateFreshnessReader(ctrl)
+			tc.mockSetup(mockRates)
+			probe, err := NewHealthProbe(zap.NewNop().Sugar(), health.NewServer(), nil, NewMockDBPinger(ctrl), time.Second, time.Second,
+				WithRateFreshness(mockRates, tc.maxAge))
+			require.NoError(t, err)
+			probe.now = func() time.Time { return now }
+
+			err = probe.CheckFreshness(context.Background())
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectStale, errors.Is(err, ErrRatesStale))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHealthProbeRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const service = "exchange.v1.RateService"
+
+	// The database becomes unreachable on the second check.
+	checked := make(chan struct{}, 1)
+	notify := func(ch chan struct{}) {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	mockDB := NewMockDBPinger(ctrl)
+	gomock.InOrder(
+		mockDB.EXPECT().PingContext(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+			notify(checked)
+			return nil
+		}),
+		mockDB.EXPECT().PingContext(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+			notify(checked)
+			return errors.New("connection refused")
+		}).MinTimes(1),
+	)
+
+	server := health.NewServer()
+	probe, err := NewHealthProbe(zap.NewNop().Sugar(), server, []string{service}, mockDB, 50*time.Millisecond, time.Second)
+	require.NoError(t, err)
+
+	status := func(name string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		probe.Run(ctx)
+		close(done)
+	}()
+
+	<-checked
+	assert.Eventually(t, func() bool {
+		return status(service) == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	<-checked
+	assert.Eventually(t, func() bool {
+		return status("") == healthpb.HealthCheckResponse_NOT_SERVING &&
+			status(service) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestHealthProbeRunStaleRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const service = "exchange.v1.AdminService"
+
+	stale := time.Now().Add(-2 * time.Hour)
+	mockDB := NewMockDBPinger(ctrl)
+	mockDB.EXPECT().PingContext(gomock.Any()).Return(nil).MinTimes(1)
+	mockRates := NewMockRateFreshnessReader(ctrl)
+	mockRates.EXPECT().LastUpdatedAt(gomock.Any()).Return(&stale, nil).MinTimes(1)
+
+	server := health.NewServer()
+	probe, err := NewHealthProbe(zap.NewNop().Sugar(), server, []string{service}, mockDB, 50*time.Millisecond, time.Second,
+		WithRateFreshness(mockRates, time.Hour))
+	require.NoError(t, err)
+
+	status := func(name string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+		if err != nil {
+			return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		return resp.Status
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		probe.Run(ctx)
+		close(done)
+	}()
+
+	// Stale rates are reported on their own service only.
+	assert.Eventually(t, func() bool {
+		return status(RatesFreshnessService) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(service))
+
+	cancel()
+	<-done
+}
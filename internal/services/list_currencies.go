@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+)
+
+// ListCurrencies returns the currency reference table ordered by code,
+// optionally filtered by the enabled flag.
+func (s *ExchangeRateService) ListCurrencies(
+	ctx context.Context,
+	req *exchangev1.ListCurrenciesRequest,
+) (*exchangev1.ListCurrenciesResponse, error) {
+
+	currencies := s.currencies.List()
+
+	resp := &exchangev1.ListCurrenciesResponse{
+		Currencies: make([]*exchangev1.Currency, 0, len(currencies)),
+	}
+	for _, c := range currencies {
+		if req.Enabled != nil && c.Enabled != req.Enabled.Value {
+			continue
+		}
+		resp.Currencies = append(resp.Currencies, toCurrencyPB(c))
+	}
+
+	return resp, nil
+}
+
+// toCurrencyPB converts a DB currency record to its protobuf representation.
+func toCurrencyPB(c models.CurrencyDB) *exchangev1.Currency {
+	return &exchangev1.Currency{
+		Code:        c.Code,
+		NumericCode: c.NumericCode,
+		Name:        c.Name,
+		Symbol:      c.Symbol,
+		MinorUnits:  c.MinorUnits,
+		Enabled:     c.Enabled,
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestListCurrencies(t *testing.T) {
+	testCases := []struct {
+		name          string
+		req           *exchangev1.ListCurrenciesRequest
+		expectedCodes []string
+	}{
+		{
+			name:          "all currencies",
+			req:           &exchangev1.ListCurrenciesRequest{},
+			expectedCodes: []string{"EUR", "KZT", "RUB", "USD"},
+		},
+		{
+			name:          "enabled only",
+			req:           &exchangev1.ListCurrenciesRequest{Enabled: wrapperspb.Bool(true)},
+			expectedCodes: []string{"EUR", "RUB", "USD"},
+		},
+		{
+			name:          "disabled only",
+			req:           &exchangev1.ListCurrenciesRequest{Enabled: wrapperspb.Bool(false)},
+			expectedCodes: []string{"KZT"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
+
+			resp, err := svc.ListCurrencies(context.Background(), tc.req)
+			require.NoError(t, err)
+
+			codes := make([]string, 0, len(resp.Currencies))
+			for _, c := range resp.Currencies {
+				codes = append(codes, c.Code)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
+
+func TestToCurrencyPB(t *testing.T) {
+	registry := newTestCurrencyRegistry(t)
+	usd, ok := registry.Get("USD")
+	require.True(t, ok)
+
+	got := toCurrencyPB(usd)
+	assert.Equal(t, "USD", got.Code)
+	assert.Equal(t, int32(840), got.NumericCode)
+	assert.Equal(t, "US Dollar", got.Name)
+	assert.Equal(t, "$", got.Symbol)
+	assert.Equal(t, int32(2), got.MinorUnits)
+	assert.True(t, got.Enabled)
+}
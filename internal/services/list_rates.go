@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+)
+
+// ListRates returns stored exchange rates as full pair records ordered by
+// from and to currency, optionally filtered by base (from) and quote (to) currency.
+// Unlike GetExchangeRates, pairs sharing a target currency do not overwrite each other.
+func (s *ExchangeRateService) ListRates(
+	ctx context.Context,
+	req *exchangev1.ListRatesRequest,
+) (*exchangev1.ListRatesResponse, error) {
+
+	if req.BaseCurrency != "" && !s.currencies.Supported(req.BaseCurrency) {
+		err := fmt.Errorf("%w: base %s", ErrUnsupportedCurrency, req.BaseCurrency)
+		s.log.Errorf("op: list rates, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	if req.QuoteCurrency != "" && !s.currencies.Supported(req.QuoteCurrency) {
+		err := fmt.Errorf("%w: quote %s", ErrUnsupportedCurrency, req.QuoteCurrency)
+		s.log.Errorf("op: list rates, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	rows, err := s.reader.List(ctx)
+	if err != nil {
+		s.log.Errorf("op: list rates, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	sortRates(rows)
+
+	resp := &exchangev1.ListRatesResponse{
+		Rates: make([]*exchangev1.ExchangeRate, 0, len(rows)),
+	}
+	for _, r := range rows {
+		if req.BaseCurrency != "" && r.FromCurrency != req.BaseCurrency {
+			continue
+		}
+		if req.QuoteCurrency != "" && r.ToCurrency != req.QuoteCurrency {
+			continue
+		}
+		resp.Rates = append(resp.Rates, toExchangeRatePB(r))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListRates(t *testing.T) {
+	updatedAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	stored := []models.ExchangeRateDB{
+		{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "EUR", Rate: decimal.RequireFromString("0.92"), UpdatedAt: updatedAt},
+		{ExchangeRateID: uuid.New(), FromCurrency: "RUB", ToCurrency: "EUR", Rate: decimal.RequireFromString("0.0101"), UpdatedAt: updatedAt},
+		{ExchangeRateID: uuid.New(), FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5"), UpdatedAt: updatedAt},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *exchangev1.ListRatesRequest
+		mockSetup     func(m *MockExchangeRateReader)
+		expectedPairs []string
+		expectedCode  codes.Code
+	}{
+		{
+			name: "all pairs without collisions",
+			req:  &exchangev1.ListRatesRequest{},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(append([]models.ExchangeRateDB(nil), stored...), nil)
+			},
+			expectedPairs: []string{"RUB:EUR", "USD:EUR", "USD:RUB"},
+		},
+		{
+			name: "base filter",
+			req:  &exchangev1.ListRatesRequest{BaseCurrency: "USD"},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(append([]models.ExchangeRateDB(nil), stored...), nil)
+			},
+			expectedPairs: []string{"USD:EUR", "USD:RUB"},
+		},
+		{
+			name: "quote filter",
+			req:  &exchangev1.ListRatesRequest{QuoteCurrency: "EUR"},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(append([]models.ExchangeRateDB(nil), stored...), nil)
+			},
+			expectedPairs: []string{"RUB:EUR", "USD:EUR"},
+		},
+		{
+			name: "base and quote filter",
+			req:  &exchangev1.ListRatesRequest{BaseCurrency: "RUB", QuoteCurrency: "EUR"},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(append([]models.ExchangeRateDB(nil), stored...), nil)
+			},
+			expectedPairs: []string{"RUB:EUR"},
+		},
+		{
+			name:         "unsupported base currency",
+			req:          &exchangev1.ListRatesRequest{BaseCurrency: "GBP"},
+			mockSetup:    func(m *MockExchangeRateReader) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "reader returns error",
+			req:  &exchangev1.ListRatesRequest{},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().List(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReader := NewMockExchangeRateReader(ctrl)
+			tc.mockSetup(mockReader)
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t))
+
+			resp, err := svc.ListRates(context.Background(), tc.req)
+
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			pairs := make([]string, 0, len(resp.Rates))
+			for _, r := range resp.Rates {
+				pairs = append(pairs, r.FromCurrency+":"+r.ToCurrency)
+				assert.True(t, r.UpdatedAt.AsTime().Equal(updatedAt))
+			}
+			assert.Equal(t, tc.expectedPairs, pairs)
+		})
+	}
+}
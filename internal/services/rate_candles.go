@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetRateCandles returns open, high, low and close rates of a currency pair over [start, end)
+// for every interval bucket aligned to start. The open of a bucket is the rate in effect at its start,
+// so buckets without changes are flat candles at the previous close.
+// Buckets before the first known rate are omitted.
+func (s *ExchangeRateService) GetRateCandles(
+	ctx context.Context,
+	req *exchangev1.RateCandlesRequest,
+) (*exchangev1.RateCandlesResponse, error) {
+
+	if s.history == nil {
+		return s.UnimplementedRateServiceServer.GetRateCandles(ctx, req)
+	}
+
+	if err := s.currencies.validatePair(req.FromCurrency, req.ToCurrency); err != nil {
+		s.log.Errorf("op: get rate candles, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	start, end, interval, err := parseHistoryRange(req.Start, req.End, req.Interval)
+	if err != nil {
+		s.log.Errorf("op: get rate candles, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	opening, err := s.history.GetAt(ctx, req.FromCurrency, req.ToCurrency, start)
+	if err != nil {
+		s.log.Errorf("op: get rate candles, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	rows, err := s.history.Candles(ctx, req.FromCurrency, req.ToCurrency, start, end, interval)
+	if err != nil {
+		s.log.Errorf("op: get rate candles, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	byBucket := make(map[int64]models.ExchangeRateCandleDB, len(rows))
+	for _, c := range rows {
+		byBucket[c.Bucket.Unix()] = c
+	}
+
+	resp := &exchangev1.RateCandlesResponse{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Interval:     req.Interval,
+	}
+	prevClose := opening
+	for bucket := start; bucket.Before(end); bucket = bucket.Add(interval) {
+		row, ok := byBucket[bucket.Unix()]
+		if !ok {
+			if prevClose == nil {
+				continue
+			}
+			row = models.ExchangeRateCandleDB{Open: *prevClose, High: *prevClose, Low: *prevClose, Close: *prevClose}
+		} else if prevClose != nil && row.FirstAt.After(bucket) {
+			// The previous close stays in effect until the first change of the bucket.
+			row.Open = *prevClose
+			row.High = decimal.Max(row.High, *prevClose)
+			row.Low = decimal.Min(row.Low, *prevClose)
+		}
+		prevClose = &row.Close
+
+		resp.Candles = append(resp.Candles, &exchangev1.RateCandle{
+			Time:    timestamppb.New(bucket),
+			Open:    toDecimalPB(row.Open),
+			High:    toDecimalPB(row.High),
+			Low:     toDecimalPB(row.Low),
+			Close:   toDecimalPB(row.Close),
+			Changes: row.Changes,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetRateCandles(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	validReq := func() *exchangev1.RateCandlesRequest {
+		return &exchangev1.RateCandlesRequest{
+			FromCurrency: "USD",
+			ToCurrency:   "RUB",
+			Start:        timestamppb.New(start),
+			End:          timestamppb.New(end),
+			Interval:     exchangev1.HistoryInterval_HISTORY_INTERVAL_HOUR,
+		}
+	}
+	candle := func(bucket time.Duration, open, high, low, close string, changes int64, firstAt time.Duration) models.ExchangeRateCandleDB {
+		return models.ExchangeRateCandleDB{
+			Bucket:  start.Add(bucket),
+			Open:    decimal.RequireFromString(open),
+			High:    decimal.RequireFromString(high),
+			Low:     decimal.RequireFromString(low),
+			Close:   decimal.RequireFromString(close),
+			Changes: changes,
+			FirstAt: start.Add(firstAt),
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		req             func() *exchangev1.RateCandlesRequest
+		mockSetup       func(m *MockExchangeRateHistoryReader)
+		expectedCandles []string // open/high/low/close/changes
+		expectedCode    codes.Code
+	}{
+		{
+			name: "previous close opens buckets and fills empty ones",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(decimalPtr("91"), nil)
+				m.EXPECT().Candles(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return([]models.ExchangeRateCandleDB{
+					candle(time.Hour, "92", "94", "92", "93", 3, 70*time.Minute),
+					candle(3*time.Hour, "90", "90", "89", "89.5", 2, 3*time.Hour+time.Minute),
+				}, nil)
+			},
+			expectedCandles: []string{
+				"91/91/91/91/0",
+				"91/94/91/93/3",
+				"93/93/93/93/0",
+				"93/93/89/89.5/2",
+			},
+		},
+		{
+			name: "change at the bucket start opens the bucket",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(decimalPtr("91"), nil)
+				m.EXPECT().Candles(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return([]models.ExchangeRateCandleDB{
+					candle(0, "92", "92", "92", "92", 1, 0),
+				}, nil)
+			},
+			expectedCandles: []string{
+				"92/92/92/92/1",
+				"92/92/92/92/0",
+				"92/92/92/92/0",
+				"92/92/92/92/0",
+			},
+		},
+		{
+			name: "buckets before the first rate are omitted",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(nil, nil)
+				m.EXPECT().Candles(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return([]models.ExchangeRateCandleDB{
+					candle(2*time.Hour, "92", "93", "91", "91.5", 4, 2*time.Hour+10*time.Minute),
+				}, nil)
+			},
+			expectedCandles: []string{
+				"92/93/91/91.5/4",
+				"91.5/91.5/91.5/91.5/0",
+			},
+		},
+		{
+			name: "no history",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(nil, nil)
+				m.EXPECT().Candles(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return(nil, nil)
+			},
+			expectedCandles: []string{},
+		},
+		{
+			name: "history reader returns error",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(decimalPtr("91"), nil)
+				m.EXPECT().Candles(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return(nil, context.DeadlineExceeded)
+			},
+			expectedCode: codes.DeadlineExceeded,
+		},
+		{
+			name: "start after end",
+			req: func() *exchangev1.RateCandlesRequest {
+				req := validReq()
+				req.Start, req.End = req.End, req.Start
+				return req
+			},
+			mockSetup:    func(m *MockExchangeRateHistoryReader) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "same currencies",
+			req: func() *exchangev1.RateCandlesRequest {
+				req := validReq()
+				req.ToCurrency = "USD"
+				return req
+			},
+			mockSetup:    func(m *MockExchangeRateHistoryReader) {},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockHistory := NewMockExchangeRateHistoryReader(ctrl)
+			tc.mockSetup(mockHistory)
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t), WithHistoryReader(mockHistory))
+
+			resp, err := svc.GetRateCandles(context.Background(), tc.req())
+
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			candles := make([]string, 0, len(resp.Candles))
+			for i, c := range resp.Candles {
+				candles = append(candles, fmt.Sprintf("%s/%s/%s/%s/%d", c.Open.Value, c.High.Value, c.Low.Value, c.Close.Value, c.Changes))
+				if i > 0 {
+					assert.Equal(t, time.Hour, c.Time.AsTime().Sub(resp.Candles[i-1].Time.AsTime()))
+				}
+			}
+			assert.Equal(t, tc.expectedCandles, candles)
+		})
+	}
+}
+
+func TestGetRateCandlesDisabled(t *testing.T) {
+	svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
+
+	_, err := svc.GetRateCandles(context.Background(), &exchangev1.RateCandlesRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxHistoryPoints is the upper bound of points returned by GetRateHistory.
+const maxHistoryPoints = 10000
+
+// ExchangeRateHistoryReader is an interface for reading the history of currency exchange rates.
+type ExchangeRateHistoryReader interface {
+	GetAt(ctx context.Context, fromCurrency, toCurrency string, asOf time.Time) (*decimal.Decimal, error)
+	History(
+		ctx context.Context,
+		fromCurrency string,
+		toCurrency string,
+		start time.Time,
+		end time.Time,
+		interval time.Duration,
+	) ([]models.ExchangeRatePointDB, error)
+	Candles(
+		ctx context.Context,
+		fromCurrency string,
+		toCurrency string,
+		start time.Time,
+		end time.Time,
+		interval time.Duration,
+	) ([]models.ExchangeRateCandleDB, error)
+}
+
+// WithHistoryReader enables GetRateHistory and GetRateCandles backed by the given history reader.
+func WithHistoryReader(history ExchangeRateHistoryReader) ExchangeRateServiceOption {
+	return func(s *ExchangeRateService) {
+		s.history = history
+	}
+}
+
+// historyIntervals maps history intervals to bucket widths.
+var historyIntervals = map[exchangev1.HistoryInterval]time.Duration{
+	exchangev1.HistoryInterval_HISTORY_INTERVAL_MINUTE: time.Minute,
+	exchangev1.HistoryInterval_HISTORY_INTERVAL_HOUR:   time.Hour,
+	exchangev1.HistoryInterval_HISTORY_INTERVAL_DAY:    24 * time.Hour,
+}
+
+// GetRateHistory returns the history of a currency pair over [start, end) as one point per
+// interval bucket aligned to start. Every point holds the last rate effective within the bucket;
+// buckets without changes carry the previous rate forward. Buckets before the first known rate are omitted.
+func (s *ExchangeRateService) GetRateHistory(
+	ctx context.Context,
+	req *exchangev1.RateHistoryRequest,
+) (*exchangev1.RateHistoryResponse, error) {
+
+	if s.history == nil {
+		return s.UnimplementedRateServiceServer.GetRateHistory(ctx, req)
+	}
+
+	if err := s.currencies.validatePair(req.FromCurrency, req.ToCurrency); err != nil {
+		s.log.Errorf("op: get rate history, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	start, end, interval, err := parseHistoryRange(req.Start, req.End, req.Interval)
+	if err != nil {
+		s.log.Errorf("op: get rate history, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	opening, err := s.history.GetAt(ctx, req.FromCurrency, req.ToCurrency, start)
+	if err != nil {
+		s.log.Errorf("op: get rate history, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	closes, err := s.history.History(ctx, req.FromCurrency, req.ToCurrency, start, end, interval)
+	if err != nil {
+		s.log.Errorf("op: get rate history, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	byBucket := make(map[int64]decimal.Decimal, len(closes))
+	for _, p := range closes {
+		byBucket[p.Bucket.Unix()] = p.Rate
+	}
+
+	resp := &exchangev1.RateHistoryResponse{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Interval:     req.Interval,
+	}
+	current := opening
+	for bucket := start; bucket.Before(end); bucket = bucket.Add(interval) {
+		if rate, ok := byBucket[bucket.Unix()]; ok {
+			current = &rate
+		}
+		if current == nil {
+			continue
+		}
+		resp.Points = append(resp.Points, &exchangev1.RatePoint{
+			Time: timestamppb.New(bucket),
+			Rate: toDecimalPB(*current),
+		})
+	}
+
+	return resp, nil
+}
+
+// parseHistoryRange validates the time range and interval of a history request.
+// The range is truncated to whole seconds since buckets are matched by Unix time.
+func parseHistoryRange(
+	startPB *timestamppb.Timestamp,
+	endPB *timestamppb.Timestamp,
+	intervalPB exchangev1.HistoryInterval,
+) (start, end time.Time, interval time.Duration, err error) {
+
+	if startPB == nil || endPB == nil {
+		err = fmt.Errorf("%w: start and end are required", ErrInvalidArgument)
+		return
+	}
+	if err = startPB.CheckValid(); err != nil {
+		err = fmt.Errorf("%w: start: %w", ErrInvalidArgument, err)
+		return
+	}
+	if err = endPB.CheckValid(); err != nil {
+		err = fmt.Errorf("%w: end: %w", ErrInvalidArgument, err)
+		return
+	}
+	start = startPB.AsTime().Truncate(time.Second)
+	end = endPB.AsTime().Truncate(time.Second)
+	if !start.Before(end) {
+		err = fmt.Errorf("%w: start must be before end: %s, %s", ErrInvalidArgument, start, end)
+		return
+	}
+
+	interval, ok := historyIntervals[intervalPB]
+	if !ok {
+		err = fmt.Errorf("%w: unsupported history interval: %s", ErrInvalidArgument, intervalPB)
+		return
+	}
+	if points := (end.Sub(start) + interval - 1) / interval; points > maxHistoryPoints {
+		err = fmt.Errorf("%w: range contains %d intervals, at most %d allowed", ErrInvalidArgument, points, maxHistoryPoints)
+		return
+	}
+
+	return
+}
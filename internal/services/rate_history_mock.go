@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /home/sergey/Github/gw-exchanger/internal/services/rate_history.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/sbilibin2017/gw-exchanger/internal/models"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockExchangeRateHistoryReader is a mock of ExchangeRateHistoryReader interface.
+type MockExchangeRateHistoryReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateHistoryReaderMockRecorder
+}
+
+// MockExchangeRateHistoryReaderMockRecorder is the mock recorder for MockExchangeRateHistoryReader.
+type MockExchangeRateHistoryReaderMockRecorder struct {
+	mock *MockExchangeRateHistoryReader
+}
+
+// NewMockExchangeRateHistoryReader creates a new mock instance.
+func NewMockExchangeRateHistoryReader(ctrl *gomock.Controller) *MockExchangeRateHistoryReader {
+	mock := &MockExchangeRateHistoryReader{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateHistoryReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateHistoryReader) EXPECT() *MockExchangeRateHistoryReaderMockRecorder {
+	return m.recorder
+}
+
+// Candles mocks base method.
+func (m *MockExchangeRateHistoryReader) Candles(ctx context.Context, fromCurrency, toCurrency string, start, end time.Time, interval time.Duration) ([]models.ExchangeRateCandleDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Candles", ctx, fromCurrency, toCurrency, start, end, interval)
+	ret0, _ := ret[0].([]models.ExchangeRateCandleDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Candles indicates an expected call of Candles.
+func (mr *MockExchangeRateHistoryReaderMockRecorder) Candles(ctx, fromCurrency, toCurrency, start, end, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Candles", reflect.TypeOf((*MockExchangeRateHistoryReader)(nil).Candles), ctx, fromCurrency, toCurrency, start, end, interval)
+}
+
+// GetAt mocks base method.
+func (m *MockExchangeRateHistoryReader) GetAt(ctx context.Context, fromCurrency, toCurrency string, asOf time.Time) (*decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAt", ctx, fromCurrency, toCurrency, asOf)
+	ret0, _ := ret[0].(*decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAt indicates an expected call of GetAt.
+func (mr *MockExchangeRateHistoryReaderMockRecorder) GetAt(ctx, fromCurrency, toCurrency, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAt", reflect.TypeOf((*MockExchangeRateHistoryReader)(nil).GetAt), ctx, fromCurrency, toCurrency, asOf)
+}
+
+// History mocks base method.
+func (m *MockExchangeRateHistoryReader) History(ctx context.Context, fromCurrency, toCurrency string, start, end time.Time, interval time.Duration) ([]models.ExchangeRatePointDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, fromCurrency, toCurrency, start, end, interval)
+	ret0, _ := ret[0].([]models.ExchangeRatePointDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockExchangeRateHistoryReaderMockRecorder) History(ctx, fromCurrency, toCurrency, start, end, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockExchangeRateHistoryReader)(nil).History), ctx, fromCurrency, toCurrency, start, end, interval)
+}
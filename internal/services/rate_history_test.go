@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetRateHistory(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	validReq := func() *exchangev1.RateHistoryRequest {
+		return &exchangev1.RateHistoryRequest{
+			FromCurrency: "USD",
+			ToCurrency:   "RUB",
+			Start:        timestamppb.New(start),
+			End:          timestamppb.New(end),
+			Interval:     exchangev1.HistoryInterval_HISTORY_INTERVAL_HOUR,
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		req            func() *exchangev1.RateHistoryRequest
+		mockSetup      func(m *MockExchangeRateHistoryReader)
+		expectedPoints []string
+		expectedCode   codes.Code
+	}{
+		{
+			name: "opening rate carried forward through empty buckets",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(decimalPtr("91"), nil)
+				m.EXPECT().History(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return([]models.ExchangeRatePointDB{
+					{Bucket: start.Add(time.Hour), Rate: decimal.RequireFromString("92")},
+					{Bucket: start.Add(3 * time.Hour), Rate: decimal.RequireFromString("93.5")},
+				}, nil)
+			},
+			expectedPoints: []string{"91", "92", "92", "93.5"},
+		},
+		{
+			name: "buckets before the first rate are omitted",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(nil, nil)
+				m.EXPECT().History(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return([]models.ExchangeRatePointDB{
+					{Bucket: start.Add(2 * time.Hour), Rate: decimal.RequireFromString("92")},
+				}, nil)
+			},
+			expectedPoints: []string{"92", "92"},
+		},
+		{
+			name: "no history",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(nil, nil)
+				m.EXPECT().History(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return(nil, nil)
+			},
+			expectedPoints: []string{},
+		},
+		{
+			name: "history reader returns error",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(decimalPtr("91"), nil)
+				m.EXPECT().History(gomock.Any(), "USD", "RUB", start, end, time.Hour).Return(nil, context.DeadlineExceeded)
+			},
+			expectedCode: codes.DeadlineExceeded,
+		},
+		{
+			name: "opening rate error",
+			req:  validReq,
+			mockSetup: func(m *MockExchangeRateHistoryReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", start).Return(nil, errors.New("db error"))
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "range is required",
+			req: func() *exchangev1.RateHistoryRequest {
+				req := validReq()
+				req.End = nil
+				return req
+			},
+			mockSetup:    func(m *MockExchangeRateHistoryReader) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "start after end",
+			req: func() *exchangev1.RateHistoryRequest {
+				req := validReq()
+				req.Start, req.End = req.End, req.Start
+				return req
+			},
+			mockSetup:    func(m *MockExchangeRateHistoryReader) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "interval is required",
+			req: func() *exchangev1.RateHistoryRequest {
+				req := validReq()
+				req.Interval = exchangev1.HistoryInterval_HISTORY_INTERVAL_UNSPECIFIED
+				return req
+			},
+			mockSetup:    func(m *MockExchangeRateHistoryReader) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "too many points",
+			req: func() *exchangev1.RateHistoryRequest {
+				req := validReq()
+				req.End = timestamppb.New(start.AddDate(0, 1, 0))
+				req.Interval = exchangev1.HistoryInterval_HISTORY_INTERVAL_MINUTE
+				return req
+			},
+			mockSetup:    func(m *MockExchangeRateHistoryReader) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "unsupported currency",
+			req: func() *exchangev1.RateHistoryRequest {
+				req := validReq()
+				req.ToCurrency = "GBP"
+				return req
+			},
+			mockSetup:    func(m *MockExchangeRateHistoryReader) {},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockHistory := NewMockExchangeRateHistoryReader(ctrl)
+			tc.mockSetup(mockHistory)
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t), WithHistoryReader(mockHistory))
+
+			resp, err := svc.GetRateHistory(context.Background(), tc.req())
+
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			rates := make([]string, 0, len(resp.Points))
+			for i, p := range resp.Points {
+				rates = append(rates, p.Rate.Value)
+				if i > 0 {
+					assert.Equal(t, time.Hour, p.Time.AsTime().Sub(resp.Points[i-1].Time.AsTime()))
+				}
+			}
+			assert.Equal(t, tc.expectedPoints, rates)
+		})
+	}
+}
+
+func TestGetRateHistoryDisabled(t *testing.T) {
+	svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
+
+	_, err := svc.GetRateHistory(context.Background(), &exchangev1.RateHistoryRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
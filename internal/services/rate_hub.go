@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"go.uber.org/zap"
+)
+
+// ErrRateHubClosed is returned to subscribers when the hub is shut down.
+var ErrRateHubClosed = errors.New("rate hub closed")
+
+// CurrencyPair identifies a currency pair.
+type CurrencyPair struct {
+	FromCurrency string
+	ToCurrency   string
+}
+
+// RateChange is a change of a stored exchange rate broadcast by RateHub.
+type RateChange struct {
+	Rate    models.ExchangeRateDB // current record; only the pair is set for deleted rates
+	Deleted bool
+}
+
+// RateHub broadcasts changes of stored exchange rates to subscribers.
+// It keeps the last known rate book and, on every Notify and reload interval,
+// reloads it from the reader and fans out the difference.
+//
+// Publishing never blocks: every subscriber has its own pending set in which
+// changes of the same pair are coalesced, so a slow subscriber only misses
+// intermediate values of a pair, never its latest value.
+type RateHub struct {
+	reader         ExchangeRateReader
+	reloadInterval time.Duration
+	notified       chan struct{}
+	log            *zap.SugaredLogger
+
+	mu          sync.Mutex
+	rates       map[string]models.ExchangeRateDB // last known rate book by pair key
+	subscribers map[*RateSubscription]struct{}
+	closed      bool
+}
+
+// NewRateHub creates a new hub over the reader.
+// reloadInterval is the period of the reload as a safety net for missed
+// notifications; zero disables it.
+func NewRateHub(
+	log *zap.SugaredLogger,
+	reader ExchangeRateReader,
+	reloadInterval time.Duration,
+) *RateHub {
+	return &RateHub{
+		reader:         reader,
+		reloadInterval: reloadInterval,
+		notified:       make(chan struct{}, 1),
+		log:            log,
+		rates:          make(map[string]models.ExchangeRateDB),
+		subscribers:    make(map[*RateSubscription]struct{}),
+	}
+}
+
+// Notify requests a reload of the rate book. It never blocks;
+// notifications arriving while a reload is pending are coalesced.
+func (h *RateHub) Notify() {
+	select {
+	case h.notified <- struct{}{}:
+	default:
+	}
+}
+
+// Reload loads the rate book from the reader and publishes its difference
+// from the last known one. On error nothing is published.
+func (h *RateHub) Reload(ctx context.Context) error {
+	rows, err := h.reader.List(ctx)
+	if err != nil {
+		h.log.Errorf("op: reload rate hub, err: %v", err)
+		return err
+	}
+
+	rates := make(map[string]models.ExchangeRateDB, len(rows))
+	for _, r := range rows {
+		rates[pairKey(r.FromCurrency, r.ToCurrency)] = r
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var changes []RateChange
+	for key, r := range rates {
+		prev, ok := h.rates[key]
+		if ok && prev.Rate.Equal(r.Rate) && prev.UpdatedAt.Equal(r.UpdatedAt) {
+			continue
+		}
+		changes = append(changes, RateChange{Rate: r})
+	}
+	for key, prev := range h.rates {
+		if _, ok := rates[key]; !ok {
+			changes = append(changes, RateChange{
+				Rate: models.ExchangeRateDB{
+					FromCurrency: prev.FromCurrency,
+					ToCurrency:   prev.ToCurrency,
+				},
+				Deleted: true,
+			})
+		}
+	}
+	h.rates = rates
+
+	if len(changes) > 0 {
+		for sub := range h.subscribers {
+			sub.offer(changes)
+		}
+		h.log.Debugf("op: reload rate hub, changes: %d, subscribers: %d", len(changes), len(h.subscribers))
+	}
+
+	return nil
+}
+
+// Run loads the initial rate book and then reloads it on every notification
+// and every reload interval until ctx is done. On return all subscriptions are closed.
+func (h *RateHub) Run(ctx context.Context) {
+	defer h.close()
+
+	h.Reload(ctx)
+
+	var tick <-chan time.Time
+	if h.reloadInterval > 0 {
+		ticker := time.NewTicker(h.reloadInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.notified:
+			h.Reload(ctx)
+		case <-tick:
+			h.Reload(ctx)
+		}
+	}
+}
+
+// Subscribe registers a subscriber for changes of the given pairs (all pairs if empty)
+// and returns it together with the current rates of those pairs ordered by pair.
+// The snapshot and the registration are atomic: no change is lost in between.
+func (h *RateHub) Subscribe(pairs []CurrencyPair) (*RateSubscription, []models.ExchangeRateDB, error) {
+	sub := &RateSubscription{
+		hub:     h,
+		pending: make(map[string]RateChange),
+		signal:  make(chan struct{}, 1),
+	}
+	if len(pairs) > 0 {
+		sub.pairs = make(map[string]struct{}, len(pairs))
+		for _, p := range pairs {
+			sub.pairs[pairKey(p.FromCurrency, p.ToCurrency)] = struct{}{}
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, ErrRateHubClosed
+	}
+
+	snapshot := make([]models.ExchangeRateDB, 0, len(h.rates))
+	for key, r := range h.rates {
+		if sub.wants(key) {
+			snapshot = append(snapshot, r)
+		}
+	}
+	sortRates(snapshot)
+
+	h.subscribers[sub] = struct{}{}
+	return sub, snapshot, nil
+}
+
+// unsubscribe removes a subscriber.
+func (h *RateHub) unsubscribe(sub *RateSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub)
+}
+
+// close closes the hub and all its subscriptions.
+func (h *RateHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		sub.close()
+	}
+	h.subscribers = make(map[*RateSubscription]struct{})
+}
+
+// RateSubscription receives rate changes from RateHub.
+type RateSubscription struct {
+	hub   *RateHub
+	pairs map[string]struct{} // nil means all pairs
+
+	mu      sync.Mutex
+	pending map[string]RateChange // latest undelivered change by pair key
+	closed  bool
+	signal  chan struct{}
+}
+
+// Next waits for changes and returns all pending ones ordered by pair.
+// It returns ErrRateHubClosed once the hub is shut down and ctx.Err() when ctx is done.
+func (s *RateSubscription) Next(ctx context.Context) ([]RateChange, error) {
+	for {
+		s.mu.Lock()
+		if len(s.pending) > 0 {
+			changes := make([]RateChange, 0, len(s.pending))
+			for _, c := range s.pending {
+				changes = append(changes, c)
+			}
+			s.pending = make(map[string]RateChange)
+			s.mu.Unlock()
+
+			sort.Slice(changes, func(i, j int) bool {
+				return lessPair(changes[i].Rate, changes[j].Rate)
+			})
+			return changes, nil
+		}
+		closed := s.closed
+		s.mu.Unlock()
+
+		if closed {
+			return nil, ErrRateHubClosed
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.signal:
+		}
+	}
+}
+
+// Close unsubscribes from the hub.
+func (s *RateSubscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// wants reports whether the subscriber is interested in a pair.
+func (s *RateSubscription) wants(key string) bool {
+	if s.pairs == nil {
+		return true
+	}
+	_, ok := s.pairs[key]
+	return ok
+}
+
+// offer adds changes to the pending set, replacing older changes of the same pair.
+func (s *RateSubscription) offer(changes []RateChange) {
+	s.mu.Lock()
+	added := false
+	for _, c := range changes {
+		key := pairKey(c.Rate.FromCurrency, c.Rate.ToCurrency)
+		if s.wants(key) {
+			s.pending[key] = c
+			added = true
+		}
+	}
+	s.mu.Unlock()
+
+	if added {
+		s.wake()
+	}
+}
+
+// close marks the subscription closed and wakes up Next.
+func (s *RateSubscription) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.wake()
+}
+
+// wake signals Next without blocking.
+func (s *RateSubscription) wake() {
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pairKey returns the map key of a currency pair.
+func pairKey(fromCurrency, toCurrency string) string {
+	return fromCurrency + ":" + toCurrency
+}
+
+// lessPair orders rates by from and to currency.
+func lessPair(a, b models.ExchangeRateDB) bool {
+	if a.FromCurrency != b.FromCurrency {
+		return a.FromCurrency < b.FromCurrency
+	}
+	return a.ToCurrency < b.ToCurrency
+}
+
+// sortRates sorts rates by from and to currency.
+func sortRates(rates []models.ExchangeRateDB) {
+	sort.Slice(rates, func(i, j int) bool {
+		return lessPair(rates[i], rates[j])
+	})
+}
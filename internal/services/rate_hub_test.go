@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testRate returns an exchange rate record updated at the given second.
+func testRate(from, to, rate string, updatedAt int64) models.ExchangeRateDB {
+	return models.ExchangeRateDB{
+		FromCurrency: from,
+		ToCurrency:   to,
+		Rate:         decimal.RequireFromString(rate),
+		UpdatedAt:    time.Unix(updatedAt, 0).UTC(),
+	}
+}
+
+func TestRateHubReload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := NewMockExchangeRateReader(ctrl)
+	hub := NewRateHub(zap.NewNop().Sugar(), mockReader, 0)
+	ctx := context.Background()
+
+	mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+		testRate("USD", "RUB", "92.5", 1),
+		testRate("USD", "EUR", "0.92", 1),
+	}, nil)
+	require.NoError(t, hub.Reload(ctx))
+
+	all, snapshot, err := hub.Subscribe(nil)
+	require.NoError(t, err)
+	defer all.Close()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, "EUR", snapshot[0].ToCurrency)
+	assert.Equal(t, "RUB", snapshot[1].ToCurrency)
+
+	usdRub, snapshot, err := hub.Subscribe([]CurrencyPair{{FromCurrency: "USD", ToCurrency: "RUB"}})
+	require.NoError(t, err)
+	defer usdRub.Close()
+	require.Len(t, snapshot, 1)
+
+	// USD->RUB changes, USD->EUR is deleted, EUR->RUB is created.
+	mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+		testRate("USD", "RUB", "93", 2),
+		testRate("EUR", "RUB", "100.5", 2),
+	}, nil)
+	require.NoError(t, hub.Reload(ctx))
+
+	changes, err := all.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "EUR", changes[0].Rate.FromCurrency)
+	assert.False(t, changes[0].Deleted)
+	assert.Equal(t, "EUR", changes[1].Rate.ToCurrency)
+	assert.True(t, changes[1].Deleted)
+	assert.True(t, decimal.RequireFromString("93").Equal(changes[2].Rate.Rate))
+
+	changes, err = usdRub.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "RUB", changes[0].Rate.ToCurrency)
+
+	// A reload without changes publishes nothing.
+	mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+		testRate("USD", "RUB", "93", 2),
+		testRate("EUR", "RUB", "100.5", 2),
+	}, nil)
+	require.NoError(t, hub.Reload(ctx))
+
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = all.Next(waitCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A failed reload keeps the last known rate book.
+	mockReader.EXPECT().List(gomock.Any()).Return(nil, errors.New("db error"))
+	assert.Error(t, hub.Reload(ctx))
+	_, snapshot, err = hub.Subscribe(nil)
+	require.NoError(t, err)
+	assert.Len(t, snapshot, 2)
+}
+
+func TestRateHubCoalescesChangesOfSlowSubscriber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := NewMockExchangeRateReader(ctrl)
+	hub := NewRateHub(zap.NewNop().Sugar(), mockReader, 0)
+	ctx := context.Background()
+
+	sub, _, err := hub.Subscribe(nil)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	// Several reloads happen while the subscriber is not reading.
+	for i, rate := range []string{"92", "93", "94"} {
+		mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+			testRate("USD", "RUB", rate, int64(i+1)),
+		}, nil)
+		require.NoError(t, hub.Reload(ctx))
+	}
+
+	changes, err := sub.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.True(t, decimal.RequireFromString("94").Equal(changes[0].Rate.Rate))
+}
+
+func TestRateHubRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := NewMockExchangeRateReader(ctrl)
+	gomock.InOrder(
+		mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+			testRate("USD", "RUB", "92", 1),
+		}, nil),
+		mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+			testRate("USD", "RUB", "93", 2),
+		}, nil).AnyTimes(),
+	)
+
+	hub := NewRateHub(zap.NewNop().Sugar(), mockReader, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		hub.Run(ctx)
+		close(done)
+	}()
+
+	// Wait for the initial load.
+	var sub *RateSubscription
+	require.Eventually(t, func() bool {
+		s, snapshot, err := hub.Subscribe(nil)
+		require.NoError(t, err)
+		if len(snapshot) == 0 {
+			s.Close()
+			return false
+		}
+		sub = s
+		return true
+	}, time.Second, 5*time.Millisecond)
+
+	hub.Notify()
+
+	nextCtx, cancelNext := context.WithTimeout(context.Background(), time.Second)
+	defer cancelNext()
+	changes, err := sub.Next(nextCtx)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.True(t, decimal.RequireFromString("93").Equal(changes[0].Rate.Rate))
+
+	// Shutting the hub down closes subscriptions and rejects new ones.
+	cancel()
+	<-done
+
+	_, err = sub.Next(context.Background())
+	assert.ErrorIs(t, err, ErrRateHubClosed)
+
+	_, _, err = hub.Subscribe(nil)
+	assert.ErrorIs(t, err, ErrRateHubClosed)
+}
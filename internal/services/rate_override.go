@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// RateOverrideReader is an interface for reading manual rate overrides.
+type RateOverrideReader interface {
+	ListActive(ctx context.Context) ([]models.RateOverrideDB, error)
+}
+
+// rateOverrideSnapshot is an immutable copy of the active overrides.
+type rateOverrideSnapshot struct {
+	overrides []models.RateOverrideDB // ordered by pair
+	byPair    map[string]models.RateOverrideDB
+}
+
+// RateOverrides keeps the active manual rate overrides in memory so that rate lookups
+// check them without a DB round trip. Overrides are reloaded on every Notify and
+// reload interval; an override stops applying at its expiry even before the next reload.
+type RateOverrides struct {
+	reader         RateOverrideReader
+	reloadInterval time.Duration
+	onChanged      func()
+	notified       chan struct{}
+	snapshot       atomic.Pointer[rateOverrideSnapshot]
+	now            func() time.Time
+	log            *zap.SugaredLogger
+}
+
+// NewRateOverrides creates a new override registry over the reader.
+// reloadInterval is the period of the reload as a safety net for missed
+// notifications; zero disables it. onChanged, if not nil, is called by Run after every
+// reload and whenever an override expires, so that readers of the overridden rates,
+// such as the rate hub, pick up the change. The registry is empty until the first Reload.
+func NewRateOverrides(
+	log *zap.SugaredLogger,
+	reader RateOverrideReader,
+	reloadInterval time.Duration,
+	onChanged func(),
+) *RateOverrides {
+	return &RateOverrides{
+		reader:         reader,
+		reloadInterval: reloadInterval,
+		onChanged:      onChanged,
+		notified:       make(chan struct{}, 1),
+		now:            time.Now,
+		log:            log,
+	}
+}
+
+// WithRateOverrides makes current rates resolve to active manual overrides before stored rates:
+// the reader of the service is wrapped with NewOverriddenExchangeRateReader and responses
+// report the overrides in effect.
+func WithRateOverrides(overrides *RateOverrides) ExchangeRateServiceOption {
+	return func(s *ExchangeRateService) {
+		s.reader = NewOverriddenExchangeRateReader(s.reader, overrides)
+		s.overrides = overrides
+	}
+}
+
+// Notify requests a reload of the overrides. It never blocks;
+// notifications arriving while a reload is pending are coalesced.
+func (o *RateOverrides) Notify() {
+	select {
+	case o.notified <- struct{}{}:
+	default:
+	}
+}
+
+// Reload loads the active overrides from the reader and swaps the in-memory copy.
+// On error the previous copy is kept.
+func (o *RateOverrides) Reload(ctx context.Context) error {
+	overrides, err := o.reader.ListActive(ctx)
+	if err != nil {
+		o.log.Errorf("op: reload rate overrides, err: %v", err)
+		return err
+	}
+
+	byPair := make(map[string]models.RateOverrideDB, len(overrides))
+	for _, ov := range overrides {
+		byPair[pairKey(ov.FromCurrency, ov.ToCurrency)] = ov
+	}
+
+	o.snapshot.Store(&rateOverrideSnapshot{
+		overrides: overrides,
+		byPair:    byPair,
+	})
+	o.log.Debugf("op: reload rate overrides, overrides: %d", len(overrides))
+
+	return nil
+}
+
+// Run reloads the overrides on every notification and every reload interval until ctx is done.
+// It reports every successful reload and every expiry of an override to onChanged.
+func (o *RateOverrides) Run(ctx context.Context) {
+	var tick <-chan time.Time
+	if o.reloadInterval > 0 {
+		ticker := time.NewTicker(o.reloadInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		var (
+			expiry  *time.Timer
+			expired <-chan time.Time
+		)
+		if wait, ok := o.nextExpiry(); ok {
+			expiry = time.NewTimer(wait)
+			expired = expiry.C
+		}
+
+		select {
+		case <-ctx.Done():
+			if expiry != nil {
+				expiry.Stop()
+			}
+			return
+		case <-o.notified:
+			o.reloadAndNotify(ctx)
+		case <-tick:
+			o.reloadAndNotify(ctx)
+		case <-expired:
+			o.log.Debugf("op: expire rate override")
+			o.changed()
+		}
+		if expiry != nil {
+			expiry.Stop()
+		}
+	}
+}
+
+// reloadAndNotify reloads the overrides and reports a successful reload to onChanged.
+func (o *RateOverrides) reloadAndNotify(ctx context.Context) {
+	if err := o.Reload(ctx); err == nil {
+		o.changed()
+	}
+}
+
+// changed calls onChanged, if set.
+func (o *RateOverrides) changed() {
+	if o.onChanged != nil {
+		o.onChanged()
+	}
+}
+
+// nextExpiry returns the time left until the earliest override in effect expires,
+// false if no override is in effect.
+func (o *RateOverrides) nextExpiry() (time.Duration, bool) {
+	overrides := o.List()
+	if len(overrides) == 0 {
+		return 0, false
+	}
+
+	earliest := overrides[0].ExpiresAt
+	for _, ov := range overrides[1:] {
+		if ov.ExpiresAt.Before(earliest) {
+			earliest = ov.ExpiresAt
+		}
+	}
+	return earliest.Sub(o.now()), true
+}
+
+// Get returns the override of a currency pair if one is in effect.
+func (o *RateOverrides) Get(fromCurrency, toCurrency string) (models.RateOverrideDB, bool) {
+	snap := o.snapshot.Load()
+	if snap == nil {
+		return models.RateOverrideDB{}, false
+	}
+
+	ov, ok := snap.byPair[pairKey(fromCurrency, toCurrency)]
+	if !ok || !o.now().Before(ov.ExpiresAt) {
+		return models.RateOverrideDB{}, false
+	}
+	return ov, true
+}
+
+// List returns the overrides in effect ordered by pair.
+func (o *RateOverrides) List() []models.RateOverrideDB {
+	snap := o.snapshot.Load()
+	if snap == nil {
+		return nil
+	}
+
+	now := o.now()
+	overrides := make([]models.RateOverrideDB, 0, len(snap.overrides))
+	for _, ov := range snap.overrides {
+		if now.Before(ov.ExpiresAt) {
+			overrides = append(overrides, ov)
+		}
+	}
+	return overrides
+}
+
+// OverriddenExchangeRateReader is an ExchangeRateReader that applies the manual overrides
+// in effect to the current rates of another reader, so that every consumer of the rate book
+// sees the same rates. Pairs that only have an override are read as if they were stored.
+// Rates effective at past instants are not overridden.
+type OverriddenExchangeRateReader struct {
+	reader    ExchangeRateReader
+	overrides *RateOverrides
+}
+
+// NewOverriddenExchangeRateReader creates a new reader applying the overrides to the reader.
+func NewOverriddenExchangeRateReader(reader ExchangeRateReader, overrides *RateOverrides) *OverriddenExchangeRateReader {
+	return &OverriddenExchangeRateReader{
+		reader:    reader,
+		overrides: overrides,
+	}
+}
+
+// Get returns the override of a currency pair while one is in effect, otherwise the stored rate.
+func (r *OverriddenExchangeRateReader) Get(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+) (*decimal.Decimal, error) {
+	if ov, ok := r.overrides.Get(fromCurrency, toCurrency); ok {
+		return &ov.Rate, nil
+	}
+	return r.reader.Get(ctx, fromCurrency, toCurrency)
+}
+
+// GetAt returns the stored rate of a currency pair effective at asOf.
+// Overrides are not kept in the rate history, so they are not applied to past rates.
+func (r *OverriddenExchangeRateReader) GetAt(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf time.Time,
+) (*decimal.Decimal, error) {
+	return r.reader.GetAt(ctx, fromCurrency, toCurrency, asOf)
+}
+
+// List returns the stored rates with the overrides in effect applied, ordered like the
+// underlying reader by created_at descending. An overridden rate takes the update time of its
+// override; a pair that only has an override is listed with the ID and times of the override.
+func (r *OverriddenExchangeRateReader) List(ctx context.Context) ([]models.ExchangeRateDB, error) {
+	rows, err := r.reader.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := r.overrides.List()
+	if len(overrides) == 0 {
+		return rows, nil
+	}
+
+	byPair := make(map[string]models.RateOverrideDB, len(overrides))
+	for _, ov := range overrides {
+		byPair[pairKey(ov.FromCurrency, ov.ToCurrency)] = ov
+	}
+
+	merged := make([]models.ExchangeRateDB, 0, len(rows)+len(overrides))
+	for _, row := range rows {
+		key := pairKey(row.FromCurrency, row.ToCurrency)
+		if ov, ok := byPair[key]; ok {
+			row.Rate = ov.Rate
+			row.UpdatedAt = ov.UpdatedAt
+			delete(byPair, key)
+		}
+		merged = append(merged, row)
+	}
+	for _, ov := range overrides {
+		if _, ok := byPair[pairKey(ov.FromCurrency, ov.ToCurrency)]; !ok {
+			continue
+		}
+		merged = append(merged, models.ExchangeRateDB{
+			ExchangeRateID: ov.RateOverrideID,
+			FromCurrency:   ov.FromCurrency,
+			ToCurrency:     ov.ToCurrency,
+			Rate:           ov.Rate,
+			CreatedAt:      ov.CreatedAt,
+			UpdatedAt:      ov.UpdatedAt,
+		})
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].CreatedAt.After(merged[j].CreatedAt)
+	})
+
+	return merged, nil
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RateOverrideWriter is an interface for managing manual rate overrides.
+type RateOverrideWriter interface {
+	ListActive(ctx context.Context) ([]models.RateOverrideDB, error)
+	Save(
+		ctx context.Context,
+		fromCurrency string,
+		toCurrency string,
+		rate decimal.Decimal,
+		reason string,
+		expiresAt time.Time,
+	) (*models.RateOverrideDB, error)
+	Delete(ctx context.Context, fromCurrency, toCurrency string) (bool, error)
+}
+
+// WithRateOverrideWriter enables SetRateOverride, DeleteRateOverride and ListRateOverrides
+// backed by the given writer. overrides, if not nil, is notified after every change
+// so that it applies on this instance without waiting for a reload.
+func WithRateOverrideWriter(writer RateOverrideWriter, overrides *RateOverrides) ExchangeRateAdminServiceOption {
+	return func(s *ExchangeRateAdminService) {
+		s.overrideWriter = writer
+		s.overrides = overrides
+	}
+}
+
+// SetRateOverride pins the rate of a currency pair until expires_at,
+// replacing the override already in effect for the pair.
+func (s *ExchangeRateAdminService) SetRateOverride(
+	ctx context.Context,
+	req *exchangev1.SetRateOverrideRequest,
+) (*exchangev1.RateOverride, error) {
+
+	if s.overrideWriter == nil {
+		return s.UnimplementedAdminServiceServer.SetRateOverride(ctx, req)
+	}
+
+	rate, expiresAt, err := parseRateOverrideInput(s.currencies, req, time.Now())
+	if err != nil {
+		s.log.Errorf("op: set rate override, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	saved, err := s.overrideWriter.Save(ctx, req.FromCurrency, req.ToCurrency, rate, req.Reason, expiresAt)
+	if err != nil {
+		s.log.Errorf("op: set rate override, err: %v", err)
+		return nil, toStatusError(err)
+	}
+	s.notifyOverrides()
+
+	s.log.Infof("op: set rate override, pair: %s -> %s, rate: %s, expires at: %s, reason: %q",
+		saved.FromCurrency, saved.ToCurrency, saved.Rate, saved.ExpiresAt, saved.Reason)
+
+	return toRateOverridePB(*saved), nil
+}
+
+// DeleteRateOverride removes the override of a currency pair.
+func (s *ExchangeRateAdminService) DeleteRateOverride(
+	ctx context.Context,
+	req *exchangev1.DeleteRateOverrideRequest,
+) (*exchangev1.DeleteRateOverrideResponse, error) {
+
+	if s.overrideWriter == nil {
+		return s.UnimplementedAdminServiceServer.DeleteRateOverride(ctx, req)
+	}
+
+	if err := s.currencies.validatePair(req.FromCurrency, req.ToCurrency); err != nil {
+		s.log.Errorf("op: delete rate override, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	deleted, err := s.overrideWriter.Delete(ctx, req.FromCurrency, req.ToCurrency)
+	if err != nil {
+		s.log.Errorf("op: delete rate override, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	if deleted {
+		s.notifyOverrides()
+	} else {
+		s.log.Warnf("op: delete rate override, override not found: %s -> %s", req.FromCurrency, req.ToCurrency)
+	}
+
+	return &exchangev1.DeleteRateOverrideResponse{
+		Deleted: deleted,
+	}, nil
+}
+
+// ListRateOverrides returns the overrides in effect ordered by currency pair.
+func (s *ExchangeRateAdminService) ListRateOverrides(
+	ctx context.Context,
+	req *exchangev1.ListRateOverridesRequest,
+) (*exchangev1.ListRateOverridesResponse, error) {
+
+	if s.overrideWriter == nil {
+		return s.UnimplementedAdminServiceServer.ListRateOverrides(ctx, req)
+	}
+
+	overrides, err := s.overrideWriter.ListActive(ctx)
+	if err != nil {
+		s.log.Errorf("op: list rate overrides, err: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	resp := &exchangev1.ListRateOverridesResponse{
+		Overrides: make([]*exchangev1.RateOverride, 0, len(overrides)),
+	}
+	for _, o := range overrides {
+		resp.Overrides = append(resp.Overrides, toRateOverridePB(o))
+	}
+
+	return resp, nil
+}
+
+// notifyOverrides requests a reload of the in-memory overrides, if any.
+func (s *ExchangeRateAdminService) notifyOverrides() {
+	if s.overrides != nil {
+		s.overrides.Notify()
+	}
+}
+
+// parseRateOverrideInput validates a set override request and returns its rate and expiry.
+func parseRateOverrideInput(
+	currencies *CurrencyRegistry,
+	req *exchangev1.SetRateOverrideRequest,
+	now time.Time,
+) (decimal.Decimal, time.Time, error) {
+
+	if err := currencies.validatePair(req.FromCurrency, req.ToCurrency); err != nil {
+		return decimal.Zero, time.Time{}, err
+	}
+
+	if req.Rate == nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("%w: rate is required", ErrInvalidArgument)
+	}
+	rate, err := fromDecimalPB(req.Rate)
+	if err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("%w: rate: %w", ErrInvalidArgument, err)
+	}
+	if err := validateStoredRate(rate); err != nil {
+		return decimal.Zero, time.Time{}, err
+	}
+
+	if req.ExpiresAt == nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("%w: expires_at is required", ErrInvalidArgument)
+	}
+	if err := req.ExpiresAt.CheckValid(); err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("%w: expires_at: %w", ErrInvalidArgument, err)
+	}
+	expiresAt := req.ExpiresAt.AsTime()
+	if !expiresAt.After(now) {
+		return decimal.Zero, time.Time{}, fmt.Errorf("%w: expires_at must be in the future: %s", ErrInvalidArgument, expiresAt)
+	}
+
+	return rate, expiresAt, nil
+}
+
+// toRateOverridePB converts a DB rate override record to its protobuf representation.
+func toRateOverridePB(o models.RateOverrideDB) *exchangev1.RateOverride {
+	return &exchangev1.RateOverride{
+		RateOverrideId: o.RateOverrideID.String(),
+		FromCurrency:   o.FromCurrency,
+		ToCurrency:     o.ToCurrency,
+		Rate:           toDecimalPB(o.Rate),
+		Reason:         o.Reason,
+		ExpiresAt:      timestamppb.New(o.ExpiresAt),
+		CreatedAt:      timestamppb.New(o.CreatedAt),
+		UpdatedAt:      timestamppb.New(o.UpdatedAt),
+	}
+}
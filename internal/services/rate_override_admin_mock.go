@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /home/sergey/Github/gw-exchanger/internal/services/rate_override_admin.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/sbilibin2017/gw-exchanger/internal/models"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockRateOverrideWriter is a mock of RateOverrideWriter interface.
+type MockRateOverrideWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateOverrideWriterMockRecorder
+}
+
+// MockRateOverrideWriterMockRecorder is the mock recorder for MockRateOverrideWriter.
+type MockRateOverrideWriterMockRecorder struct {
+	mock *MockRateOverrideWriter
+}
+
+// NewMockRateOverrideWriter creates a new mock instance.
+func NewMockRateOverrideWriter(ctrl *gomock.Controller) *MockRateOverrideWriter {
+	mock := &MockRateOverrideWriter{ctrl: ctrl}
+	mock.recorder = &MockRateOverrideWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateOverrideWriter) EXPECT() *MockRateOverrideWriterMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRateOverrideWriter) Delete(ctx context.Context, fromCurrency, toCurrency string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, fromCurrency, toCurrency)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRateOverrideWriterMockRecorder) Delete(ctx, fromCurrency, toCurrency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRateOverrideWriter)(nil).Delete), ctx, fromCurrency, toCurrency)
+}
+
+// ListActive mocks base method.
+func (m *MockRateOverrideWriter) ListActive(ctx context.Context) ([]models.RateOverrideDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx)
+	ret0, _ := ret[0].([]models.RateOverrideDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockRateOverrideWriterMockRecorder) ListActive(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockRateOverrideWriter)(nil).ListActive), ctx)
+}
+
+// Save mocks base method.
+func (m *MockRateOverrideWriter) Save(ctx context.Context, fromCurrency, toCurrency string, rate decimal.Decimal, reason string, expiresAt time.Time) (*models.RateOverrideDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, fromCurrency, toCurrency, rate, reason, expiresAt)
+	ret0, _ := ret[0].(*models.RateOverrideDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRateOverrideWriterMockRecorder) Save(ctx, fromCurrency, toCurrency, rate, reason, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRateOverrideWriter)(nil).Save), ctx, fromCurrency, toCurrency, rate, reason, expiresAt)
+}
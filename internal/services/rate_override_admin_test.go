@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSetRateOverride(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	expiresAt := now.Add(time.Hour)
+	id := uuid.New()
+
+	testCases := []struct {
+		name         string
+		req          *exchangev1.SetRateOverrideRequest
+		mockSetup    func(m *MockRateOverrideWriter)
+		expectedCode codes.Code
+	}{
+		{
+			name: "override saved",
+			req: &exchangev1.SetRateOverrideRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Rate:         &exchangev1.Decimal{Value: "95.5"},
+				Reason:       "provider outage",
+				ExpiresAt:    timestamppb.New(expiresAt),
+			},
+			mockSetup: func(m *MockRateOverrideWriter) {
+				rate := decimal.RequireFromString("95.5")
+				m.EXPECT().
+					Save(gomock.Any(), "USD", "RUB", rate, "provider outage", expiresAt).
+					Return(&models.RateOverrideDB{
+						RateOverrideID: id, FromCurrency: "USD", ToCurrency: "RUB", Rate: rate,
+						Reason: "provider outage", ExpiresAt: expiresAt, CreatedAt: now, UpdatedAt: now,
+					}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "writer returns error",
+			req: &exchangev1.SetRateOverrideRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Rate:         &exchangev1.Decimal{Value: "95.5"},
+				ExpiresAt:    timestamppb.New(expiresAt),
+			},
+			mockSetup: func(m *MockRateOverrideWriter) {
+				m.EXPECT().
+					Save(gomock.Any(), "USD", "RUB", decimal.RequireFromString("95.5"), "", expiresAt).
+					Return(nil, errors.New("db error"))
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "expiry in the past",
+			req: &exchangev1.SetRateOverrideRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Rate:         &exchangev1.Decimal{Value: "95.5"},
+				ExpiresAt:    timestamppb.New(now.Add(-time.Minute)),
+			},
+			mockSetup:    func(m *MockRateOverrideWriter) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "missing expiry",
+			req: &exchangev1.SetRateOverrideRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Rate:         &exchangev1.Decimal{Value: "95.5"},
+			},
+			mockSetup:    func(m *MockRateOverrideWriter) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "missing rate",
+			req: &exchangev1.SetRateOverrideRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				ExpiresAt:    timestamppb.New(expiresAt),
+			},
+			mockSetup:    func(m *MockRateOverrideWriter) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "non-positive rate",
+			req: &exchangev1.SetRateOverrideRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Rate:         &exchangev1.Decimal{Value: "-1"},
+				ExpiresAt:    timestamppb.New(expiresAt),
+			},
+			mockSetup:    func(m *MockRateOverrideWriter) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "rate with more than six decimal places",
+			req: &exchangev1.SetRateOverrideRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "RUB",
+				Rate:         &exchangev1.Decimal{Value: "92.1234567"},
+				ExpiresAt:    timestamppb.New(expiresAt),
+			},
+			mockSetup:    func(m *MockRateOverrideWriter) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "unsupported currency",
+			req: &exchangev1.SetRateOverrideRequest{
+				FromCurrency: "KZT",
+				ToCurrency:   "RUB",
+				Rate:         &exchangev1.Decimal{Value: "0.2"},
+				ExpiresAt:    timestamppb.New(expiresAt),
+			},
+			mockSetup:    func(m *MockRateOverrideWriter) {},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWriter := NewMockRateOverrideWriter(ctrl)
+			tc.mockSetup(mockWriter)
+			svc := NewExchangeRateAdminService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t),
+				WithRateOverrideWriter(mockWriter, nil))
+
+			resp, err := svc.SetRateOverride(context.Background(), tc.req)
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode != codes.OK {
+				assert.Nil(t, resp)
+				return
+			}
+			require.NotNil(t, resp)
+			assert.Equal(t, id.String(), resp.RateOverrideId)
+			assert.Equal(t, "95.5", resp.Rate.Value)
+			assert.Equal(t, "provider outage", resp.Reason)
+			assert.True(t, expiresAt.Equal(resp.ExpiresAt.AsTime()))
+		})
+	}
+}
+
+func TestSetRateOverrideNotifiesRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expiresAt := time.Now().Add(time.Hour)
+	mockWriter := NewMockRateOverrideWriter(ctrl)
+	mockWriter.EXPECT().
+		Save(gomock.Any(), "USD", "RUB", gomock.Any(), "", gomock.Any()).
+		Return(&models.RateOverrideDB{FromCurrency: "USD", ToCurrency: "RUB", ExpiresAt: expiresAt}, nil)
+	overrides := NewRateOverrides(zap.NewNop().Sugar(), NewMockRateOverrideReader(ctrl), 0, nil)
+	svc := NewExchangeRateAdminService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t),
+		WithRateOverrideWriter(mockWriter, overrides))
+
+	_, err := svc.SetRateOverride(context.Background(), &exchangev1.SetRateOverrideRequest{
+		FromCurrency: "USD",
+		ToCurrency:   "RUB",
+		Rate:         &exchangev1.Decimal{Value: "95"},
+		ExpiresAt:    timestamppb.New(expiresAt),
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-overrides.notified:
+	default:
+		t.Fatal("override registry was not notified")
+	}
+}
+
+func TestDeleteRateOverride(t *testing.T) {
+	testCases := []struct {
+		name          string
+		req           *exchangev1.DeleteRateOverrideRequest
+		mockSetup     func(m *MockRateOverrideWriter)
+		expectError   bool
+		expectDeleted bool
+	}{
+		{
+			name: "override deleted",
+			req:  &exchangev1.DeleteRateOverrideRequest{FromCurrency: "USD", ToCurrency: "RUB"},
+			mockSetup: func(m *MockRateOverrideWriter) {
+				m.EXPECT().Delete(gomock.Any(), "USD", "RUB").Return(true, nil)
+			},
+			expectDeleted: true,
+		},
+		{
+			name: "override not found",
+			req:  &exchangev1.DeleteRateOverrideRequest{FromCurrency: "USD", ToCurrency: "EUR"},
+			mockSetup: func(m *MockRateOverrideWriter) {
+				m.EXPECT().Delete(gomock.Any(), "USD", "EUR").Return(false, nil)
+			},
+			expectDeleted: false,
+		},
+		{
+			name: "writer returns error",
+			req:  &exchangev1.DeleteRateOverrideRequest{FromCurrency: "USD", ToCurrency: "RUB"},
+			mockSetup: func(m *MockRateOverrideWriter) {
+				m.EXPECT().Delete(gomock.Any(), "USD", "RUB").Return(false, errors.New("db error"))
+			},
+			expectError: true,
+		},
+		{
+			name:        "unsupported currency",
+			req:         &exchangev1.DeleteRateOverrideRequest{FromCurrency: "USD", ToCurrency: "JPY"},
+			mockSetup:   func(m *MockRateOverrideWriter) {},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWriter := NewMockRateOverrideWriter(ctrl)
+			tc.mockSetup(mockWriter)
+			svc := NewExchangeRateAdminService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t),
+				WithRateOverrideWriter(mockWriter, nil))
+
+			resp, err := svc.DeleteRateOverride(context.Background(), tc.req)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				assert.Equal(t, tc.expectDeleted, resp.Deleted)
+			}
+		})
+	}
+}
+
+func TestListRateOverrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expiresAt := time.Now().Add(time.Hour)
+	mockWriter := NewMockRateOverrideWriter(ctrl)
+	mockWriter.EXPECT().ListActive(gomock.Any()).Return([]models.RateOverrideDB{
+		{FromCurrency: "EUR", ToCurrency: "RUB", Rate: decimal.RequireFromString("105"), ExpiresAt: expiresAt},
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("95"), ExpiresAt: expiresAt},
+	}, nil)
+	svc := NewExchangeRateAdminService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t),
+		WithRateOverrideWriter(mockWriter, nil))
+
+	resp, err := svc.ListRateOverrides(context.Background(), &exchangev1.ListRateOverridesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Overrides, 2)
+	assert.Equal(t, "EUR", resp.Overrides[0].FromCurrency)
+	assert.Equal(t, "95", resp.Overrides[1].Rate.Value)
+
+	mockWriter.EXPECT().ListActive(gomock.Any()).Return(nil, errors.New("db error"))
+	_, err = svc.ListRateOverrides(context.Background(), &exchangev1.ListRateOverridesRequest{})
+	assert.Error(t, err)
+}
+
+func TestRateOverrideRPCsUnimplemented(t *testing.T) {
+	svc := NewExchangeRateAdminService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t))
+
+	_, err := svc.SetRateOverride(context.Background(), &exchangev1.SetRateOverrideRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = svc.DeleteRateOverride(context.Background(), &exchangev1.DeleteRateOverrideRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = svc.ListRateOverrides(context.Background(), &exchangev1.ListRateOverridesRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /home/sergey/Github/gw-exchanger/internal/services/rate_override.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/sbilibin2017/gw-exchanger/internal/models"
+)
+
+// MockRateOverrideReader is a mock of RateOverrideReader interface.
+type MockRateOverrideReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateOverrideReaderMockRecorder
+}
+
+// MockRateOverrideReaderMockRecorder is the mock recorder for MockRateOverrideReader.
+type MockRateOverrideReaderMockRecorder struct {
+	mock *MockRateOverrideReader
+}
+
+// NewMockRateOverrideReader creates a new mock instance.
+func NewMockRateOverrideReader(ctrl *gomock.Controller) *MockRateOverrideReader {
+	mock := &MockRateOverrideReader{ctrl: ctrl}
+	mock.recorder = &MockRateOverrideReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateOverrideReader) EXPECT() *MockRateOverrideReaderMockRecorder {
+	return m.recorder
+}
+
+// ListActive mocks base method.
+func (m *MockRateOverrideReader) ListActive(ctx context.Context) ([]models.RateOverrideDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx)
+	ret0, _ := ret[0].([]models.RateOverrideDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockRateOverrideReaderMockRecorder) ListActive(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockRateOverrideReader)(nil).ListActive), ctx)
+}
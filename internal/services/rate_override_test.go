@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	pb "github.com/sbilibin2017/proto-exchange/exchange"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestRateOverrides returns an override registry loaded with the given overrides
+// whose clock is fixed at now.
+func newTestRateOverrides(t *testing.T, now time.Time, overrides ...models.RateOverrideDB) *RateOverrides {
+	ctrl := gomock.NewController(t)
+	mockReader := NewMockRateOverrideReader(ctrl)
+	mockReader.EXPECT().ListActive(gomock.Any()).Return(overrides, nil)
+
+	registry := NewRateOverrides(zap.NewNop().Sugar(), mockReader, 0, nil)
+	registry.now = func() time.Time { return now }
+	require.NoError(t, registry.Reload(context.Background()))
+	return registry
+}
+
+func TestRateOverrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	usdRub := models.RateOverrideDB{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("95"), ExpiresAt: now.Add(time.Hour)}
+	eurRub := models.RateOverrideDB{FromCurrency: "EUR", ToCurrency: "RUB", Rate: decimal.RequireFromString("105"), ExpiresAt: now.Add(2 * time.Hour)}
+
+	mockReader := NewMockRateOverrideReader(ctrl)
+	registry := NewRateOverrides(zap.NewNop().Sugar(), mockReader, 0, nil)
+	registry.now = func() time.Time { return now }
+
+	// Nothing applies until the first reload.
+	_, ok := registry.Get("USD", "RUB")
+	assert.False(t, ok)
+
+	mockReader.EXPECT().ListActive(gomock.Any()).Return([]models.RateOverrideDB{eurRub, usdRub}, nil)
+	require.NoError(t, registry.Reload(context.Background()))
+
+	o, ok := registry.Get("USD", "RUB")
+	require.True(t, ok)
+	assert.Equal(t, "95", o.Rate.String())
+	_, ok = registry.Get("RUB", "USD")
+	assert.False(t, ok, "overrides are directional")
+	assert.Len(t, registry.List(), 2)
+
+	// A failed reload keeps the previous overrides.
+	mockReader.EXPECT().ListActive(gomock.Any()).Return(nil, errors.New("db error"))
+	assert.Error(t, registry.Reload(context.Background()))
+	_, ok = registry.Get("USD", "RUB")
+	assert.True(t, ok)
+
+	// An override stops applying at its expiry without a reload.
+	now = now.Add(time.Hour)
+	_, ok = registry.Get("USD", "RUB")
+	assert.False(t, ok)
+	assert.Equal(t, []models.RateOverrideDB{eurRub}, registry.List())
+}
+
+func TestRateOverridesRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reloaded := make(chan struct{}, 1)
+	mockReader := NewMockRateOverrideReader(ctrl)
+	mockReader.EXPECT().ListActive(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]models.RateOverrideDB, error) {
+		select {
+		case reloaded <- struct{}{}:
+		default:
+		}
+		return []models.RateOverrideDB{
+			{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("95"), ExpiresAt: time.Now().Add(time.Hour)},
+		}, nil
+	}).MinTimes(1)
+
+	// The reload interval is disabled, so only Notify triggers a reload.
+	registry := NewRateOverrides(zap.NewNop().Sugar(), mockReader, 0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		registry.Run(ctx)
+		close(done)
+	}()
+
+	registry.Notify()
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("overrides were not reloaded")
+	}
+	cancel()
+	<-done
+
+	_, ok := registry.Get("USD", "RUB")
+	assert.True(t, ok)
+}
+
+func TestRateOverridesRunNotifiesOnExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := NewMockRateOverrideReader(ctrl)
+	mockReader.EXPECT().ListActive(gomock.Any()).Return([]models.RateOverrideDB{
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("95"), ExpiresAt: time.Now().Add(50 * time.Millisecond)},
+	}, nil)
+
+	changed := make(chan struct{}, 2)
+	registry := NewRateOverrides(zap.NewNop().Sugar(), mockReader, 0, func() { changed <- struct{}{} })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		registry.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// The reload is reported first, then the expiry without another reload.
+	registry.Notify()
+	for _, event := range []string{"reload", "expiry"} {
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Fatalf("%s was not reported", event)
+		}
+	}
+	_, ok := registry.Get("USD", "RUB")
+	assert.False(t, ok)
+}
+
+func TestOverriddenExchangeRateReader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	overrideID := uuid.New()
+	overrides := newTestRateOverrides(t, now,
+		models.RateOverrideDB{FromCurrency: "EUR", ToCurrency: "USD", Rate: decimal.RequireFromString("1.1"), ExpiresAt: now.Add(time.Hour), CreatedAt: now.Add(-time.Minute), UpdatedAt: now.Add(-time.Minute), RateOverrideID: overrideID},
+		models.RateOverrideDB{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("95"), ExpiresAt: now.Add(time.Hour), CreatedAt: now.Add(-time.Minute), UpdatedAt: now.Add(-time.Minute)},
+	)
+
+	mockReader := NewMockExchangeRateReader(ctrl)
+	reader := NewOverriddenExchangeRateReader(mockReader, overrides)
+
+	rate, err := reader.Get(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	assert.Equal(t, "95", rate.String())
+
+	mockReader.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(decimalPtr("100"), nil)
+	rate, err = reader.Get(context.Background(), "EUR", "RUB")
+	require.NoError(t, err)
+	assert.Equal(t, "100", rate.String())
+
+	mockReader.EXPECT().GetAt(gomock.Any(), "USD", "RUB", now).Return(decimalPtr("92.5"), nil)
+	rate, err = reader.GetAt(context.Background(), "USD", "RUB", now)
+	require.NoError(t, err)
+	assert.Equal(t, "92.5", rate.String(), "past rates are not overridden")
+
+	mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5"), CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour)},
+		{FromCurrency: "EUR", ToCurrency: "RUB", Rate: decimal.RequireFromString("100"), CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-2 * time.Hour)},
+	}, nil)
+	rows, err := reader.List(context.Background())
+	require.NoError(t, err)
+
+	got := make([]string, 0, len(rows))
+	for _, r := range rows {
+		got = append(got, r.FromCurrency+":"+r.ToCurrency+"="+r.Rate.String())
+	}
+	assert.Equal(t, []string{"EUR:USD=1.1", "USD:RUB=95", "EUR:RUB=100"}, got, "ordered by created_at descending")
+	assert.Equal(t, overrideID, rows[0].ExchangeRateID, "a pair with only an override is listed with its ID")
+	assert.True(t, now.Add(-time.Minute).Equal(rows[1].UpdatedAt), "an overridden rate takes the update time of its override")
+
+	mockReader.EXPECT().List(gomock.Any()).Return(nil, errors.New("db error"))
+	_, err = reader.List(context.Background())
+	assert.Error(t, err)
+}
+
+func TestListRatesWithOverrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	overrides := newTestRateOverrides(t, now,
+		models.RateOverrideDB{FromCurrency: "EUR", ToCurrency: "USD", Rate: decimal.RequireFromString("1.1"), ExpiresAt: now.Add(time.Hour)},
+		models.RateOverrideDB{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("95"), ExpiresAt: now.Add(time.Hour)},
+	)
+
+	mockReader := NewMockExchangeRateReader(ctrl)
+	mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+		{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("92.5")},
+	}, nil).Times(2)
+	svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t), WithRateOverrides(overrides))
+
+	listed, err := svc.ListRates(context.Background(), &exchangev1.ListRatesRequest{})
+	require.NoError(t, err)
+	got := make([]string, 0, len(listed.Rates))
+	for _, r := range listed.Rates {
+		got = append(got, r.FromCurrency+":"+r.ToCurrency+"="+r.RateDecimal.Value)
+	}
+	assert.Equal(t, []string{"EUR:USD=1.1", "USD:RUB=95"}, got)
+
+	all, err := svc.GetExchangeRates(context.Background(), &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float32{"USD": 1.1, "RUB": 95}, all.Rates)
+}
+
+func TestGetRateOverride(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	overrides := newTestRateOverrides(t, now,
+		models.RateOverrideDB{FromCurrency: "USD", ToCurrency: "RUB", Rate: decimal.RequireFromString("95"), ExpiresAt: expiresAt},
+		models.RateOverrideDB{FromCurrency: "EUR", ToCurrency: "USD", Rate: decimal.RequireFromString("1.1"), ExpiresAt: expiresAt},
+	)
+
+	testCases := []struct {
+		name           string
+		req            *exchangev1.RateRequest
+		mockSetup      func(m *MockExchangeRateReader)
+		expectedRate   string
+		expectOverride bool
+		expectedLegs   []bool // override flag of every leg
+	}{
+		{
+			name:           "override takes precedence over stored rate",
+			req:            &exchangev1.RateRequest{FromCurrency: "USD", ToCurrency: "RUB"},
+			mockSetup:      func(m *MockExchangeRateReader) {},
+			expectedRate:   "95",
+			expectOverride: true,
+		},
+		{
+			name: "pair without override uses stored rate",
+			req:  &exchangev1.RateRequest{FromCurrency: "RUB", ToCurrency: "USD"},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "RUB", "USD").Return(decimalPtr("0.0108"), nil)
+			},
+			expectedRate: "0.0108",
+		},
+		{
+			name: "historical rate ignores override",
+			req:  &exchangev1.RateRequest{FromCurrency: "USD", ToCurrency: "RUB", AsOf: timestamppb.New(now.Add(-time.Hour))},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().GetAt(gomock.Any(), "USD", "RUB", gomock.Any()).Return(decimalPtr("92.5"), nil)
+			},
+			expectedRate: "92.5",
+		},
+		{
+			name: "override used as a leg of a derived rate",
+			req:  &exchangev1.RateRequest{FromCurrency: "EUR", ToCurrency: "RUB"},
+			mockSetup: func(m *MockExchangeRateReader) {
+				m.EXPECT().Get(gomock.Any(), "EUR", "RUB").Return(nil, nil)
+				m.EXPECT().List(gomock.Any()).Return(nil, nil)
+			},
+			expectedRate: "104.5",
+			expectedLegs: []bool{true, true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReader := NewMockExchangeRateReader(ctrl)
+			tc.mockSetup(mockReader)
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t),
+				WithRateOverrides(overrides), WithPivotCurrency("USD"))
+
+			resp, err := svc.GetRate(context.Background(), tc.req)
+
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			assert.True(t, decimal.RequireFromString(tc.expectedRate).Equal(decimal.RequireFromString(resp.RateDecimal.Value)))
+			assert.Equal(t, tc.expectOverride, resp.Override)
+			if tc.expectOverride {
+				assert.True(t, expiresAt.Equal(resp.OverrideExpiresAt.AsTime()))
+			} else {
+				assert.Nil(t, resp.OverrideExpiresAt)
+			}
+			legs := make([]bool, 0, len(resp.Legs))
+			for _, leg := range resp.Legs {
+				legs = append(legs, leg.Override)
+			}
+			assert.ElementsMatch(t, tc.expectedLegs, legs)
+		})
+	}
+}
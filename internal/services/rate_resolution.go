@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+// inversePrecision is the number of decimal places kept in derived inverse rates.
+const inversePrecision = 12
+
+// ErrAsymmetricRate is returned under InversePolicyStrict when the stored rates
+// of both directions of a pair are not inverse of each other within the tolerance.
+var ErrAsymmetricRate = errors.New("asymmetric exchange rate pair")
+
+// InversePolicy defines how rates are derived from the opposite direction of a pair.
+type InversePolicy string
+
+// Supported inverse policies.
+const (
+	// InversePolicyNone disables inverse derivation.
+	InversePolicyNone InversePolicy = ""
+	// InversePolicyPreferStored uses the stored rate and derives 1/rate only if it is missing.
+	InversePolicyPreferStored InversePolicy = "prefer_stored"
+	// InversePolicyPreferDerived uses 1/rate of the opposite direction whenever it is stored.
+	InversePolicyPreferDerived InversePolicy = "prefer_derived"
+	// InversePolicyStrict behaves like InversePolicyPreferStored but rejects pairs whose
+	// stored directions multiply to a value deviating from 1 by more than the tolerance.
+	InversePolicyStrict InversePolicy = "strict"
+)
+
+// ParseInversePolicy parses an inverse policy name.
+func ParseInversePolicy(s string) (InversePolicy, error) {
+	switch p := InversePolicy(s); p {
+	case InversePolicyNone, InversePolicyPreferStored, InversePolicyPreferDerived, InversePolicyStrict:
+		return p, nil
+	default:
+		return InversePolicyNone, fmt.Errorf("unknown inverse policy: %s", s)
+	}
+}
+
+// rateLeg is a stored exchange rate used to derive another rate.
+type rateLeg struct {
+	fromCurrency string
+	toCurrency   string
+	rate         decimal.Decimal
+	override     bool // the rate is a manual override
+}
+
+// resolvedRate is an exchange rate either stored directly or derived from other rates.
+type resolvedRate struct {
+	rate     decimal.Decimal
+	derived  bool
+	legs     []rateLeg              // legs the rate was derived from, empty for stored rates
+	override *models.RateOverrideDB // override in effect for a stored rate, nil if none
+}
+
+// resolveRate returns the rate for a currency pair effective at asOf (current if nil).
+// The pair is first resolved directly or through its inverse according to the inverse policy;
+// if that fails and a pivot currency is configured, the rate is derived as
+// from->pivot * pivot->to. It returns nil if no rate can be found.
+func (s *ExchangeRateService) resolveRate(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf *time.Time,
+) (*resolvedRate, error) {
+
+	pair, err := s.resolvePair(ctx, fromCurrency, toCurrency, asOf)
+	if err != nil || pair != nil {
+		return pair, err
+	}
+
+	return s.triangulate(ctx, fromCurrency, toCurrency, asOf)
+}
+
+// resolvePair returns the stored rate for a currency pair or derives it from
+// the opposite direction according to the inverse policy.
+func (s *ExchangeRateService) resolvePair(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf *time.Time,
+) (*resolvedRate, error) {
+
+	switch s.inversePolicy {
+	case InversePolicyPreferStored:
+		direct, err := s.direct(ctx, fromCurrency, toCurrency, asOf)
+		if err != nil || direct != nil {
+			return direct, err
+		}
+		return s.inverse(ctx, fromCurrency, toCurrency, asOf)
+
+	case InversePolicyPreferDerived:
+		inverse, err := s.inverse(ctx, fromCurrency, toCurrency, asOf)
+		if err != nil || inverse != nil {
+			return inverse, err
+		}
+		return s.direct(ctx, fromCurrency, toCurrency, asOf)
+
+	case InversePolicyStrict:
+		direct, err := s.direct(ctx, fromCurrency, toCurrency, asOf)
+		if err != nil {
+			return nil, err
+		}
+		inverse, err := s.inverse(ctx, fromCurrency, toCurrency, asOf)
+		if err != nil {
+			return nil, err
+		}
+		if direct != nil && inverse != nil {
+			deviation := direct.rate.Mul(inverse.legs[0].rate).Sub(decimal.NewFromInt(1)).Abs()
+			if deviation.GreaterThan(s.inverseTolerance) {
+				return nil, fmt.Errorf("%w: %s -> %s = %s, %s -> %s = %s",
+					ErrAsymmetricRate,
+					fromCurrency, toCurrency, direct.rate,
+					toCurrency, fromCurrency, inverse.legs[0].rate,
+				)
+			}
+		}
+		if direct != nil {
+			return direct, nil
+		}
+		return inverse, nil
+
+	default:
+		return s.direct(ctx, fromCurrency, toCurrency, asOf)
+	}
+}
+
+// direct returns the stored rate for a currency pair, nil if it is not stored.
+func (s *ExchangeRateService) direct(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf *time.Time,
+) (*resolvedRate, error) {
+
+	rate, override, err := s.lookupRate(ctx, fromCurrency, toCurrency, asOf)
+	if err != nil || rate == nil {
+		return nil, err
+	}
+
+	return &resolvedRate{rate: *rate, override: override}, nil
+}
+
+// inverse derives the rate for a currency pair as 1/rate of the opposite direction,
+// nil if the opposite direction is not stored.
+func (s *ExchangeRateService) inverse(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf *time.Time,
+) (*resolvedRate, error) {
+
+	opposite, override, err := s.lookupRate(ctx, toCurrency, fromCurrency, asOf)
+	if err != nil || opposite == nil {
+		return nil, err
+	}
+	if opposite.IsZero() {
+		return nil, fmt.Errorf("zero exchange rate: %s -> %s", toCurrency, fromCurrency)
+	}
+
+	return &resolvedRate{
+		rate:    decimal.NewFromInt(1).DivRound(*opposite, inversePrecision),
+		derived: true,
+		legs: []rateLeg{
+			{fromCurrency: toCurrency, toCurrency: fromCurrency, rate: *opposite, override: override != nil},
+		},
+	}, nil
+}
+
+// triangulate derives the rate for a currency pair through the pivot currency.
+// Each leg is resolved with the inverse policy applied; a leg derived from
+// the opposite direction is reported as that stored rate.
+// It returns nil if no pivot is configured or one of the legs is missing.
+func (s *ExchangeRateService) triangulate(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf *time.Time,
+) (*resolvedRate, error) {
+
+	pivot := s.pivotCurrency
+	if pivot == "" || pivot == fromCurrency || pivot == toCurrency {
+		return nil, nil
+	}
+
+	first, err := s.resolvePair(ctx, fromCurrency, pivot, asOf)
+	if err != nil || first == nil {
+		return nil, err
+	}
+	second, err := s.resolvePair(ctx, pivot, toCurrency, asOf)
+	if err != nil || second == nil {
+		return nil, err
+	}
+
+	legs := first.storedLegs(fromCurrency, pivot)
+	legs = append(legs, second.storedLegs(pivot, toCurrency)...)
+
+	return &resolvedRate{
+		rate:    first.rate.Mul(second.rate),
+		derived: true,
+		legs:    legs,
+	}, nil
+}
+
+// storedLegs returns the stored rates the rate of a currency pair was resolved from:
+// the legs of a derived rate or the rate itself if it is stored.
+func (r *resolvedRate) storedLegs(fromCurrency, toCurrency string) []rateLeg {
+	if r.derived {
+		return append([]rateLeg(nil), r.legs...)
+	}
+	return []rateLeg{
+		{fromCurrency: fromCurrency, toCurrency: toCurrency, rate: r.rate, override: r.override != nil},
+	}
+}
+
+// legUpdateTimes returns the update time of the stored rate of every leg by pair, read from
+// the current rate book. Update times of past rates are not stored, so it returns nil
+// for rates effective at asOf.
+func (s *ExchangeRateService) legUpdateTimes(
+	ctx context.Context,
+	legs []rateLeg,
+	asOf *time.Time,
+) (map[string]time.Time, error) {
+	if len(legs) == 0 || asOf != nil {
+		return nil, nil
+	}
+
+	rows, err := s.reader.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]struct{}, len(legs))
+	for _, leg := range legs {
+		wanted[pairKey(leg.fromCurrency, leg.toCurrency)] = struct{}{}
+	}
+	times := make(map[string]time.Time, len(legs))
+	for _, r := range rows {
+		key := pairKey(r.FromCurrency, r.ToCurrency)
+		if _, ok := wanted[key]; ok {
+			times[key] = r.UpdatedAt
+		}
+	}
+	return times, nil
+}
+
+// lookupRate reads the rate for a currency pair effective at asOf (current if nil).
+// Overrides are applied by the reader; for a current rate the override in effect is
+// returned along with the rate if the rate is taken from it.
+func (s *ExchangeRateService) lookupRate(
+	ctx context.Context,
+	fromCurrency string,
+	toCurrency string,
+	asOf *time.Time,
+) (*decimal.Decimal, *models.RateOverrideDB, error) {
+	if asOf != nil {
+		rate, err := s.reader.GetAt(ctx, fromCurrency, toCurrency, *asOf)
+		return rate, nil, err
+	}
+
+	rate, err := s.reader.Get(ctx, fromCurrency, toCurrency)
+	if err != nil || rate == nil || s.overrides == nil {
+		return rate, nil, err
+	}
+	if o, ok := s.overrides.Get(fromCurrency, toCurrency); ok && o.Rate.Equal(*rate) {
+		return rate, &o, nil
+	}
+	return rate, nil, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"google.golang.org/grpc"
+)
+
+// SubscribeRates streams changes of stored exchange rates for the requested pairs
+// (all pairs if none requested). The first message is a snapshot of the current rates;
+// every following message carries the changes accumulated since the previous one.
+func (s *ExchangeRateService) SubscribeRates(
+	req *exchangev1.SubscribeRatesRequest,
+	stream grpc.ServerStreamingServer[exchangev1.SubscribeRatesResponse],
+) error {
+
+	if s.hub == nil {
+		return s.UnimplementedRateServiceServer.SubscribeRates(req, stream)
+	}
+
+	pairs := make([]CurrencyPair, 0, len(req.Pairs))
+	for i, p := range req.Pairs {
+		if err := s.currencies.validatePair(p.FromCurrency, p.ToCurrency); err != nil {
+			err = fmt.Errorf("pairs[%d]: %w", i, err)
+			s.log.Errorf("op: subscribe rates, err: %v", err)
+			return toStatusError(err)
+		}
+		pairs = append(pairs, CurrencyPair{FromCurrency: p.FromCurrency, ToCurrency: p.ToCurrency})
+	}
+
+	sub, snapshot, err := s.hub.Subscribe(pairs)
+	if err != nil {
+		s.log.Errorf("op: subscribe rates, err: %v", err)
+		return toStatusError(err)
+	}
+	defer sub.Close()
+
+	resp := &exchangev1.SubscribeRatesResponse{
+		Snapshot: true,
+		Updated:  make([]*exchangev1.ExchangeRate, 0, len(snapshot)),
+	}
+	for _, r := range snapshot {
+		resp.Updated = append(resp.Updated, toExchangeRatePB(r))
+	}
+	if err := stream.Send(resp); err != nil {
+		s.log.Errorf("op: subscribe rates, err: %v", err)
+		return err
+	}
+
+	ctx := stream.Context()
+	for {
+		changes, err := sub.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			s.log.Errorf("op: subscribe rates, err: %v", err)
+			return toStatusError(err)
+		}
+
+		resp := &exchangev1.SubscribeRatesResponse{}
+		for _, c := range changes {
+			if c.Deleted {
+				resp.Deleted = append(resp.Deleted, &exchangev1.CurrencyPair{
+					FromCurrency: c.Rate.FromCurrency,
+					ToCurrency:   c.Rate.ToCurrency,
+				})
+				continue
+			}
+			resp.Updated = append(resp.Updated, toExchangeRatePB(c.Rate))
+		}
+		if err := stream.Send(resp); err != nil {
+			s.log.Errorf("op: subscribe rates, err: %v", err)
+			return err
+		}
+	}
+}
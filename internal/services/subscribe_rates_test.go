@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	exchangev1 "github.com/sbilibin2017/gw-exchanger/api/proto/exchange/v1"
+	"github.com/sbilibin2017/gw-exchanger/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRatesStream is a server stream of SubscribeRates collecting sent messages.
+type fakeRatesStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *exchangev1.SubscribeRatesResponse
+}
+
+func (s *fakeRatesStream) Context() context.Context { return s.ctx }
+
+func (s *fakeRatesStream) Send(resp *exchangev1.SubscribeRatesResponse) error {
+	s.sent <- resp
+	return nil
+}
+
+func TestSubscribeRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := NewMockExchangeRateReader(ctrl)
+	hub := NewRateHub(zap.NewNop().Sugar(), mockReader, 0)
+	svc := NewExchangeRateService(zap.NewNop().Sugar(), mockReader, newTestCurrencyRegistry(t), WithRateHub(hub))
+
+	mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+		testRate("USD", "RUB", "92", 1),
+		testRate("USD", "EUR", "0.92", 1),
+	}, nil)
+	require.NoError(t, hub.Reload(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeRatesStream{ctx: ctx, sent: make(chan *exchangev1.SubscribeRatesResponse, 4)}
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.SubscribeRates(&exchangev1.SubscribeRatesRequest{
+			Pairs: []*exchangev1.CurrencyPair{
+				{FromCurrency: "USD", ToCurrency: "RUB"},
+				{FromCurrency: "USD", ToCurrency: "EUR"},
+			},
+		}, stream)
+	}()
+
+	first := <-stream.sent
+	assert.True(t, first.Snapshot)
+	require.Len(t, first.Updated, 2)
+	assert.Equal(t, "EUR", first.Updated[0].ToCurrency)
+	assert.Equal(t, "RUB", first.Updated[1].ToCurrency)
+
+	mockReader.EXPECT().List(gomock.Any()).Return([]models.ExchangeRateDB{
+		testRate("USD", "RUB", "93", 2),
+		testRate("EUR", "RUB", "100", 2),
+	}, nil)
+	require.NoError(t, hub.Reload(context.Background()))
+
+	var next *exchangev1.SubscribeRatesResponse
+	select {
+	case next = <-stream.sent:
+	case <-time.After(time.Second):
+		t.Fatal("no update received")
+	}
+	assert.False(t, next.Snapshot)
+	require.Len(t, next.Updated, 1)
+	assert.Equal(t, "93", next.Updated[0].RateDecimal.Value)
+	require.Len(t, next.Deleted, 1)
+	assert.Equal(t, "EUR", next.Deleted[0].ToCurrency)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestSubscribeRatesErrors(t *testing.T) {
+	testCases := []struct {
+		name         string
+		req          *exchangev1.SubscribeRatesRequest
+		withHub      bool
+		expectedCode codes.Code
+	}{
+		{
+			name:         "streaming disabled",
+			req:          &exchangev1.SubscribeRatesRequest{},
+			expectedCode: codes.Unimplemented,
+		},
+		{
+			name: "unsupported currency",
+			req: &exchangev1.SubscribeRatesRequest{
+				Pairs: []*exchangev1.CurrencyPair{{FromCurrency: "USD", ToCurrency: "GBP"}},
+			},
+			withHub:      true,
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []ExchangeRateServiceOption
+			if tc.withHub {
+				opts = append(opts, WithRateHub(NewRateHub(zap.NewNop().Sugar(), nil, 0)))
+			}
+			svc := NewExchangeRateService(zap.NewNop().Sugar(), nil, newTestCurrencyRegistry(t), opts...)
+
+			stream := &fakeRatesStream{ctx: context.Background(), sent: make(chan *exchangev1.SubscribeRatesResponse, 1)}
+			err := svc.SubscribeRates(tc.req, stream)
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
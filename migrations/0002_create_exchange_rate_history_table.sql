@@ -0,0 +1,35 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS exchange_rate_history (
+    exchange_rate_history_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_currency VARCHAR(3) NOT NULL,
+    to_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(18,6) NOT NULL,
+    effective_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rate_history_pair_effective_at
+    ON exchange_rate_history (from_currency, to_currency, effective_at DESC);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_exchange_rate_history() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO exchange_rate_history (from_currency, to_currency, rate, effective_at)
+    VALUES (NEW.from_currency, NEW.to_currency, NEW.rate, COALESCE(NEW.updated_at, NOW()));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER exchange_rates_record_history
+    AFTER INSERT OR UPDATE OF rate ON exchange_rates
+    FOR EACH ROW EXECUTE FUNCTION record_exchange_rate_history();
+
+INSERT INTO exchange_rate_history (from_currency, to_currency, rate, effective_at)
+SELECT from_currency, to_currency, rate, COALESCE(updated_at, created_at, NOW())
+FROM exchange_rates;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS exchange_rates_record_history ON exchange_rates;
+DROP FUNCTION IF EXISTS record_exchange_rate_history();
+DROP TABLE IF EXISTS exchange_rate_history;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_exchange_rates_changed() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('exchange_rates_changed', TG_OP);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER exchange_rates_notify_changed
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON exchange_rates
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_exchange_rates_changed();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS exchange_rates_notify_changed ON exchange_rates;
+DROP FUNCTION IF EXISTS notify_exchange_rates_changed();
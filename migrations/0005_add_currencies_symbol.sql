@@ -0,0 +1,54 @@
+-- +goose Up
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS symbol VARCHAR(8) NOT NULL DEFAULT '';
+
+UPDATE currencies AS c SET symbol = s.symbol
+FROM (VALUES
+    ('AED', 'د.إ'),
+    ('AMD', '֏'),
+    ('AUD', 'A$'),
+    ('AZN', '₼'),
+    ('BGN', 'лв'),
+    ('BRL', 'R$'),
+    ('BYN', 'Br'),
+    ('CAD', 'C$'),
+    ('CHF', 'CHF'),
+    ('CNY', '¥'),
+    ('CZK', 'Kč'),
+    ('DKK', 'kr'),
+    ('EUR', '€'),
+    ('GBP', '£'),
+    ('GEL', '₾'),
+    ('HKD', 'HK$'),
+    ('HUF', 'Ft'),
+    ('IDR', 'Rp'),
+    ('ILS', '₪'),
+    ('INR', '₹'),
+    ('ISK', 'kr'),
+    ('JPY', '¥'),
+    ('KGS', 'с'),
+    ('KRW', '₩'),
+    ('KZT', '₸'),
+    ('MDL', 'L'),
+    ('MXN', 'Mex$'),
+    ('NOK', 'kr'),
+    ('NZD', 'NZ$'),
+    ('PHP', '₱'),
+    ('PLN', 'zł'),
+    ('RON', 'lei'),
+    ('RSD', 'дин.'),
+    ('RUB', '₽'),
+    ('SEK', 'kr'),
+    ('SGD', 'S$'),
+    ('THB', '฿'),
+    ('TJS', 'SM'),
+    ('TRY', '₺'),
+    ('UAH', '₴'),
+    ('USD', '$'),
+    ('UZS', 'soʻm'),
+    ('VND', '₫'),
+    ('ZAR', 'R')
+) AS s (code, symbol)
+WHERE c.code = s.code;
+
+-- +goose Down
+ALTER TABLE currencies DROP COLUMN IF EXISTS symbol;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS rate_quotes (
+    rate_quote_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source VARCHAR(32) NOT NULL,
+    from_currency VARCHAR(3) NOT NULL,
+    to_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(30,12) NOT NULL,
+    quoted_at TIMESTAMPTZ NOT NULL,
+    outlier BOOLEAN NOT NULL DEFAULT FALSE,
+    method VARCHAR(16) NOT NULL,
+    aggregated_rate DECIMAL(18,6) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_quotes_pair_created_at
+    ON rate_quotes (from_currency, to_currency, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS rate_quotes;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS rate_overrides (
+    rate_override_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_currency VARCHAR(3) NOT NULL,
+    to_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(18,6) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(from_currency, to_currency)
+);
+
+CREATE TRIGGER rate_overrides_notify_changed
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON rate_overrides
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_exchange_rates_changed();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS rate_overrides_notify_changed ON rate_overrides;
+DROP TABLE IF EXISTS rate_overrides;
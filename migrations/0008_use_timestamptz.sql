@@ -0,0 +1,20 @@
+-- +goose Up
+-- Rate timestamps are stored as absolute instants so that comparisons with UTC values
+-- passed by the service, such as the as-of instant of a rate lookup, do not depend on
+-- the TimeZone of the database session. Values filled by NOW() were written in the session time zone.
+ALTER TABLE exchange_rates
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE exchange_rate_history
+    ALTER COLUMN effective_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE exchange_rate_history
+    ALTER COLUMN effective_at TYPE TIMESTAMP WITHOUT TIME ZONE,
+    ALTER COLUMN created_at TYPE TIMESTAMP WITHOUT TIME ZONE;
+
+ALTER TABLE exchange_rates
+    ALTER COLUMN created_at TYPE TIMESTAMP WITHOUT TIME ZONE,
+    ALTER COLUMN updated_at TYPE TIMESTAMP WITHOUT TIME ZONE;
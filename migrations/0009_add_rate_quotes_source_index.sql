@@ -0,0 +1,7 @@
+-- +goose Up
+-- The rate providers pipeline reads the latest quote of every source and pair.
+CREATE INDEX IF NOT EXISTS idx_rate_quotes_source_pair_quoted_at
+    ON rate_quotes (source, from_currency, to_currency, quoted_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_rate_quotes_source_pair_quoted_at;
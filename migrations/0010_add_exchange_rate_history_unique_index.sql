@@ -0,0 +1,48 @@
+-- +goose Up
+-- A pair has at most one historical rate per instant, so that importing the same file
+-- twice does not duplicate the history. Duplicates left by earlier imports are removed
+-- first, keeping the latest recorded one.
+DELETE FROM exchange_rate_history
+WHERE exchange_rate_history_id IN (
+    SELECT exchange_rate_history_id
+    FROM (
+        SELECT exchange_rate_history_id,
+               ROW_NUMBER() OVER (
+                   PARTITION BY from_currency, to_currency, effective_at
+                   ORDER BY created_at DESC NULLS LAST, exchange_rate_history_id
+               ) AS n
+        FROM exchange_rate_history
+    ) ranked
+    WHERE n > 1
+);
+
+DROP INDEX IF EXISTS idx_exchange_rate_history_pair_effective_at;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exchange_rate_history_pair_effective_at
+    ON exchange_rate_history (from_currency, to_currency, effective_at);
+
+-- Rates written twice at the same instant, e.g. in one transaction, keep the last one.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_exchange_rate_history() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO exchange_rate_history (from_currency, to_currency, rate, effective_at)
+    VALUES (NEW.from_currency, NEW.to_currency, NEW.rate, COALESCE(NEW.updated_at, NOW()))
+    ON CONFLICT (from_currency, to_currency, effective_at) DO UPDATE SET rate = EXCLUDED.rate;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_exchange_rate_history() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO exchange_rate_history (from_currency, to_currency, rate, effective_at)
+    VALUES (NEW.from_currency, NEW.to_currency, NEW.rate, COALESCE(NEW.updated_at, NOW()));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_exchange_rate_history_pair_effective_at;
+CREATE INDEX IF NOT EXISTS idx_exchange_rate_history_pair_effective_at
+    ON exchange_rate_history (from_currency, to_currency, effective_at DESC);
@@ -0,0 +1,7 @@
+-- +goose Up
+-- The health probe reads the time of the latest fetched quote to check the rates freshness.
+CREATE INDEX IF NOT EXISTS idx_rate_quotes_quoted_at
+    ON rate_quotes (quoted_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_rate_quotes_quoted_at;
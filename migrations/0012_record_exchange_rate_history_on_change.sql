@@ -0,0 +1,22 @@
+-- +goose Up
+-- An update that keeps the rate of a pair, such as saving the same rate again,
+-- is not a rate change and is not recorded in the history.
+DROP TRIGGER IF EXISTS exchange_rates_record_history ON exchange_rates;
+
+CREATE TRIGGER exchange_rates_record_history_insert
+    AFTER INSERT ON exchange_rates
+    FOR EACH ROW EXECUTE FUNCTION record_exchange_rate_history();
+
+CREATE TRIGGER exchange_rates_record_history_update
+    AFTER UPDATE OF rate ON exchange_rates
+    FOR EACH ROW
+    WHEN (OLD.rate IS DISTINCT FROM NEW.rate)
+    EXECUTE FUNCTION record_exchange_rate_history();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS exchange_rates_record_history_update ON exchange_rates;
+DROP TRIGGER IF EXISTS exchange_rates_record_history_insert ON exchange_rates;
+
+CREATE TRIGGER exchange_rates_record_history
+    AFTER INSERT OR UPDATE OF rate ON exchange_rates
+    FOR EACH ROW EXECUTE FUNCTION record_exchange_rate_history();
@@ -0,0 +1,52 @@
+// Package migrations embeds the SQL migrations of the service database
+// so that the binary applies them without an external goose install.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+	"go.uber.org/zap"
+)
+
+// FS holds the SQL migrations of this directory.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// NewProvider returns a goose provider of the migrations in fsys, or of the embedded ones if fsys is nil.
+// Migrations are run under a PostgreSQL session-level advisory lock,
+// so replicas migrating the same database at once apply every migration exactly once.
+func NewProvider(db *sql.DB, fsys fs.FS) (*goose.Provider, error) {
+	if fsys == nil {
+		fsys = FS
+	}
+
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
+	}
+
+	return goose.NewProvider(goose.DialectPostgres, db, fsys, goose.WithSessionLocker(locker))
+}
+
+// Up applies all pending migrations of the provider and logs every applied one.
+func Up(ctx context.Context, log *zap.SugaredLogger, provider *goose.Provider) error {
+	results, err := provider.Up(ctx)
+	for _, r := range results {
+		log.Infof("Migration applied: %s (%s)", r.Source.Path, r.Duration)
+	}
+	if err != nil {
+		log.Errorf("op: apply migrations, err: %v", err)
+		return err
+	}
+
+	if len(results) == 0 {
+		log.Info("No pending migrations")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFS(t *testing.T) {
+	files, err := fs.Glob(FS, "*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, name := range files {
+		data, err := fs.ReadFile(FS, name)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "-- +goose Up", name)
+		assert.Contains(t, string(data), "-- +goose Down", name)
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	provider, err := NewProvider(db, nil)
+	require.NoError(t, err)
+
+	// Versions are numbered without gaps, so a missing or misnamed file is caught early.
+	sources := provider.ListSources()
+	require.NotEmpty(t, sources)
+	for i, s := range sources {
+		assert.Equal(t, int64(i+1), s.Version, fmt.Sprintf("source %s", s.Path))
+	}
+}